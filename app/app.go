@@ -2,6 +2,7 @@ package app
 
 import (
 	"btcwalletapi/config"
+	"btcwalletapi/cryto/segwit"
 	"btcwalletapi/routes/btc/walletapi"
 	"fmt"
 	"log"
@@ -24,6 +25,10 @@ func (a *App) GetRouter() *mux.Router{
 	return a.router
 }
 
+func (a *App) GetConfig() config.Config{
+	return a.config
+}
+
 func (a *App) Run(){
 	fmt.Println("Starting the application...")
 
@@ -59,6 +64,13 @@ func NewApp() App {
 		os.Exit(1)
 	}
 
+	// fail fast on a default network requests could not fall back to
+	_, err = segwit.ParseNetwork(conf.Bitcoin.Network)
+	if err != nil {
+		log.Printf("bitcoin.network %q: %v\n", conf.Bitcoin.Network, err)
+		os.Exit(1)
+	}
+
 	return App{
 		router: r,
 		config: conf,
@@ -14,6 +14,15 @@ type Config struct {
 			Port string `yaml:"port"`
 		} `yaml:"http"`
 	} `yaml:"application"`
+	Bitcoin struct {
+		Network       string `yaml:"network"`
+		MaxRangeCount uint32 `yaml:"max_range_count"`
+		// KeyExport allow private keys to leave the service, disabled by default
+		KeyExport bool `yaml:"key_export"`
+		// LegacyUncompressedKeys derive BIP44/49/84 addresses from uncompressed public keys like the releases
+		// before BIP49/BIP84 compliance did, for wallets relying on those addresses
+		LegacyUncompressedKeys bool `yaml:"legacy_uncompressed_keys"`
+	} `yaml:"bitcoin"`
 }
 
 func GetConfig() (Config, error) {
@@ -0,0 +1,214 @@
+package bech32m
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/btcsuite/btcutil/bech32"
+)
+
+// Encoding checksum variant of a bech32 string
+type Encoding int
+
+const (
+	// Bech32 BIP173 checksum, used by witness version 0
+	Bech32 Encoding = iota + 1
+	// Bech32m BIP350 checksum, used by witness version 1 onwards
+	Bech32m
+)
+
+const (
+	bech32Const  = 1
+	bech32mConst = 0x2bc830a3
+	charset      = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+	maxLength    = 90
+)
+
+var gen = []int{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+var (
+	ErrMixedCase          = errors.New("mixed case string")
+	ErrInvalidLength      = errors.New("invalid string length")
+	ErrInvalidSeparator   = errors.New("invalid separator position")
+	ErrInvalidCharacter   = errors.New("invalid character")
+	ErrInvalidChecksum    = errors.New("invalid checksum")
+	ErrInvalidWitnessVer  = errors.New("invalid witness version")
+	ErrInvalidProgramSize = errors.New("invalid witness program length")
+	// ErrBech32mExpected witness version 1+ encoded with a bech32 checksum
+	ErrBech32mExpected = errors.New("witness version 1+ requires a bech32m checksum")
+	// ErrBech32Expected witness version 0 encoded with a bech32m checksum
+	ErrBech32Expected = errors.New("witness version 0 requires a bech32 checksum")
+)
+
+func polymod(values []byte) int {
+	chk := 1
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ int(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+func hrpExpand(hrp string) []byte {
+	result := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		result = append(result, hrp[i]>>5)
+	}
+	result = append(result, 0)
+	for i := 0; i < len(hrp); i++ {
+		result = append(result, hrp[i]&31)
+	}
+	return result
+}
+
+func checksumConst(enc Encoding) int {
+	if enc == Bech32m {
+		return bech32mConst
+	}
+	return bech32Const
+}
+
+func createChecksum(hrp string, data []byte, enc Encoding) []byte {
+	values := append(hrpExpand(hrp), data...)
+	values = append(values, 0, 0, 0, 0, 0, 0)
+	mod := polymod(values) ^ checksumConst(enc)
+	result := make([]byte, 6)
+	for i := 0; i < 6; i++ {
+		result[i] = byte((mod >> uint(5*(5-i))) & 31)
+	}
+	return result
+}
+
+// Encode encode 5 bits groups with the human readable part and the checksum variant
+func Encode(hrp string, data []byte, enc Encoding) (string, error) {
+	var result strings.Builder
+
+	hrp = strings.ToLower(hrp)
+	result.WriteString(hrp)
+	result.WriteByte('1')
+	for _, b := range append(data, createChecksum(hrp, data, enc)...) {
+		if int(b) >= len(charset) {
+			return "", ErrInvalidCharacter
+		}
+		result.WriteByte(charset[b])
+	}
+
+	if result.Len() > maxLength {
+		return "", ErrInvalidLength
+	}
+
+	return result.String(), nil
+}
+
+// Decode decode a bech32 or bech32m string to its human readable part, 5 bits groups and checksum variant
+func Decode(s string) (string, []byte, Encoding, error) {
+	if len(s) < 8 || len(s) > maxLength {
+		return "", nil, 0, ErrInvalidLength
+	}
+
+	lower := strings.ToLower(s)
+	if s != lower && s != strings.ToUpper(s) {
+		return "", nil, 0, ErrMixedCase
+	}
+	s = lower
+
+	pos := strings.LastIndexByte(s, '1')
+	if pos < 1 || pos+7 > len(s) {
+		return "", nil, 0, ErrInvalidSeparator
+	}
+
+	hrp := s[:pos]
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return "", nil, 0, ErrInvalidCharacter
+		}
+	}
+
+	data := make([]byte, 0, len(s)-pos-1)
+	for i := pos + 1; i < len(s); i++ {
+		d := strings.IndexByte(charset, s[i])
+		if d < 0 {
+			return "", nil, 0, ErrInvalidCharacter
+		}
+		data = append(data, byte(d))
+	}
+
+	var enc Encoding
+	switch polymod(append(hrpExpand(hrp), data...)) {
+	case bech32Const:
+		enc = Bech32
+	case bech32mConst:
+		enc = Bech32m
+	default:
+		return "", nil, 0, ErrInvalidChecksum
+	}
+
+	return hrp, data[:len(data)-6], enc, nil
+}
+
+// EncodeSegWitAddress encode a witness program to a segwit address, bech32 for version 0 and bech32m onwards
+func EncodeSegWitAddress(hrp string, version byte, program []byte) (string, error) {
+	if version > 16 {
+		return "", ErrInvalidWitnessVer
+	}
+	if err := validateProgram(version, program); err != nil {
+		return "", err
+	}
+
+	converted, err := bech32.ConvertBits(program, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+
+	enc := Bech32m
+	if version == 0 {
+		enc = Bech32
+	}
+
+	return Encode(hrp, append([]byte{version}, converted...), enc)
+}
+
+// DecodeSegWitAddress decode a segwit address to its human readable part, witness version and program
+func DecodeSegWitAddress(address string) (string, byte, []byte, error) {
+	hrp, data, enc, err := Decode(address)
+	if err != nil {
+		return "", 0, nil, err
+	}
+	if len(data) < 1 || data[0] > 16 {
+		return "", 0, nil, ErrInvalidWitnessVer
+	}
+
+	version := data[0]
+	switch {
+	case version == 0 && enc != Bech32:
+		return "", 0, nil, ErrBech32Expected
+	case version != 0 && enc != Bech32m:
+		return "", 0, nil, ErrBech32mExpected
+	}
+
+	program, err := bech32.ConvertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return "", 0, nil, ErrInvalidProgramSize
+	}
+	if err := validateProgram(version, program); err != nil {
+		return "", 0, nil, err
+	}
+
+	return hrp, version, program, nil
+}
+
+// validateProgram check the witness program length rules of BIP141
+func validateProgram(version byte, program []byte) error {
+	if len(program) < 2 || len(program) > 40 {
+		return ErrInvalidProgramSize
+	}
+	if version == 0 && len(program) != 20 && len(program) != 32 {
+		return ErrInvalidProgramSize
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package bech32m
+
+import (
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	var _, _, enc, err = Decode("a1lqfn3a")
+
+	assert.NoError(t, err, "Expected no error: valid bech32m string")
+	assert.Equal(t, Bech32m, enc, "Expected bech32m checksum")
+
+	_, _, enc, err = Decode("A12UEL5L")
+
+	assert.NoError(t, err, "Expected no error: valid bech32 string")
+	assert.Equal(t, Bech32, enc, "Expected bech32 checksum")
+
+	_, _, _, err = Decode("a1lqfn3q")
+
+	assert.Equal(t, ErrInvalidChecksum, err, "Expected error: invalid checksum")
+
+	_, _, _, err = Decode("A1lqfn3a")
+
+	assert.Equal(t, ErrMixedCase, err, "Expected error: mixed case")
+}
+
+func TestSegWitAddress(t *testing.T) {
+	var program, _ = hex.DecodeString("79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798")
+	var address, err = EncodeSegWitAddress("bc", 1, program)
+
+	assert.NoError(t, err, "Expected no error: valid witness program")
+	assert.Equal(t, "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0", address, "Incorrect taproot address")
+
+	hrp, version, decoded, err := DecodeSegWitAddress(address)
+
+	assert.NoError(t, err, "Expected no error: valid address")
+	assert.Equal(t, "bc", hrp, "Incorrect human readable part")
+	assert.Equal(t, byte(1), version, "Incorrect witness version")
+	assert.Equal(t, program, decoded, "Incorrect witness program")
+
+	_, _, _, err = DecodeSegWitAddress("bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4")
+
+	assert.NoError(t, err, "Expected no error: valid version 0 address")
+
+	// BIP350 invalid vector: version 1 with a bech32 checksum
+	_, _, _, err = DecodeSegWitAddress("bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqh2y7hd")
+
+	assert.Equal(t, ErrBech32mExpected, err, "Expected error: bech32 checksum on version 1")
+
+	// BIP350 invalid vector: version 0 with a bech32m checksum
+	_, _, _, err = DecodeSegWitAddress("bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kemeawh")
+
+	assert.Equal(t, ErrBech32Expected, err, "Expected error: bech32m checksum on version 0")
+}
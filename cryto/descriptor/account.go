@@ -0,0 +1,76 @@
+package descriptor
+
+import (
+	"btcwalletapi/cryto/segwit"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// AccountDescriptors the ranged receive and change descriptors of a seed account, with xpub keys
+// for watch-only wallets and xprv keys for spending wallets
+type AccountDescriptors struct {
+	Fingerprint    []byte
+	Receive        string
+	Change         string
+	ReceivePrivate string
+	ChangePrivate  string
+}
+
+// purposeFunction wrap a key expression in the descriptor of the address type a purpose stands for
+func purposeFunction(purpose segwit.Purpose, key string) (string, error) {
+	switch purpose {
+	case segwit.PurposeBIP44:
+		return "pkh(" + key + ")", nil
+	case segwit.PurposeBIP49:
+		return "sh(wpkh(" + key + "))", nil
+	case segwit.PurposeBIP84:
+		return "wpkh(" + key + ")", nil
+	case segwit.PurposeBIP86:
+		return "tr(" + key + ")", nil
+	default:
+		return "", segwit.ErrUnsupportedPurpose
+	}
+}
+
+// Account give the descriptors with checksum of a BIP44/49/84/86 seed account m/purpose'/coin_type'/account',
+// [fingerprint/purpose'/coin_type'/account']key/chain/*
+func Account(seed []byte, network *chaincfg.Params, purpose, coinType, account uint32) (*AccountDescriptors, error) {
+	km, err := segwit.NewKeyManager(seed, network)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := km.GetAccountKey(purpose, coinType, account)
+	if err != nil {
+		return nil, err
+	}
+	fingerprint, err := km.MasterFingerprint()
+	if err != nil {
+		return nil, err
+	}
+
+	var origin = keyOrigin(fingerprint, []uint32{purpose, coinType, account})
+	var private = extendedKey(key.ExtendedKey(), network)
+	var public = extendedKey(key.ExtendedKey().PublicKey(), network)
+
+	var result = &AccountDescriptors{Fingerprint: fingerprint}
+	for _, descriptor := range []struct {
+		target *string
+		key    string
+		chain  uint32
+	}{
+		{&result.Receive, public, segwit.ChainReceive},
+		{&result.Change, public, segwit.ChainChange},
+		{&result.ReceivePrivate, private, segwit.ChainReceive},
+		{&result.ChangePrivate, private, segwit.ChainChange},
+	} {
+		body, err := purposeFunction(purpose, rangedKey(origin, descriptor.key, []uint32{descriptor.chain}))
+		if err != nil {
+			return nil, err
+		}
+		if *descriptor.target, err = AddChecksum(body); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,47 @@
+package descriptor
+
+import (
+	"btcwalletapi/cryto/mnemonic"
+	"btcwalletapi/cryto/segwit"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestAccount(t *testing.T) {
+	var seed, _ = mnemonic.ToSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "")
+	var descriptors, err = Account(seed, &chaincfg.MainNetParams, segwit.PurposeBIP84, segwit.CoinTypeBTC, segwit.Apostrophe)
+
+	assert.NoError(t, err, "Expected no error: valid account")
+	assert.Equal(t, []byte{0x73, 0xc5, 0xda, 0x0a}, descriptors.Fingerprint, "Incorrect fingerprint")
+	assert.Equal(t, "wpkh([73c5da0a/84'/0'/0']"+testBIP84XPub+"/0/*)", strings.Split(descriptors.Receive, "#")[0], "Incorrect receive descriptor")
+	assert.Equal(t, "wpkh([73c5da0a/84'/0'/0']"+testBIP84XPub+"/1/*)", strings.Split(descriptors.Change, "#")[0], "Incorrect change descriptor")
+	assert.True(t, strings.HasPrefix(descriptors.ReceivePrivate, "wpkh([73c5da0a/84'/0'/0']xprv"), "Expected an xprv key")
+	assert.True(t, strings.HasSuffix(strings.Split(descriptors.ChangePrivate, "#")[0], "/1/*)"), "Expected the change chain")
+
+	for _, descriptor := range []string{descriptors.Receive, descriptors.Change, descriptors.ReceivePrivate, descriptors.ChangePrivate} {
+		_, err = SplitChecksum(descriptor, true)
+
+		assert.NoError(t, err, "Expected no error: valid checksum")
+	}
+
+	// the exported descriptor derives the addresses of the account
+	parsed, err := Parse(descriptors.Receive, nil)
+
+	assert.NoError(t, err, "Expected no error: exported descriptor")
+
+	address, _ := parsed.Address(0)
+
+	assert.Equal(t, "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu", address.Address, "Incorrect address")
+
+	descriptors, err = Account(seed, &chaincfg.TestNet3Params, segwit.PurposeBIP86, segwit.CoinTypeTestnet, segwit.Apostrophe)
+
+	assert.NoError(t, err, "Expected no error: valid testnet account")
+	assert.True(t, strings.HasPrefix(descriptors.Receive, "tr([73c5da0a/86'/1'/0']tpub"), "Expected a tpub key")
+	assert.True(t, strings.HasPrefix(descriptors.ReceivePrivate, "tr([73c5da0a/86'/1'/0']tprv"), "Expected a tprv key")
+
+	_, err = Account(seed, &chaincfg.MainNetParams, segwit.Apostrophe+48, segwit.CoinTypeBTC, segwit.Apostrophe)
+
+	assert.Equal(t, segwit.ErrUnsupportedPurpose, err, "Expected error: unsupported purpose")
+}
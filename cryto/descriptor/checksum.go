@@ -0,0 +1,118 @@
+package descriptor
+
+import (
+	"errors"
+	"strings"
+)
+
+// inputCharset characters allowed in a descriptor, grouped by 32 as the checksum expects (BIP380)
+const inputCharset = "0123456789()[],'/*abcdefgh@:$%{}" +
+	"IJKLMNOPQRSTUVWXYZ&+-.;<=>?!^_|~" +
+	"ijklmnopqrstuvwxyzABCDEFGH`#\"\\ "
+
+// checksumCharset bech32 characters of the checksum
+const checksumCharset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// checksumLength number of characters of a descriptor checksum
+const checksumLength = 8
+
+var (
+	ErrInvalidDescriptorCharacter = errors.New("invalid descriptor character")
+	ErrInvalidChecksum            = errors.New("invalid descriptor checksum")
+	ErrMissingChecksum            = errors.New("missing descriptor checksum")
+)
+
+// polymod the BCH code generator of descriptor checksums
+func polymod(c uint64, value int) uint64 {
+	c0 := c >> 35
+	c = ((c & 0x7ffffffff) << 5) ^ uint64(value)
+	if c0&1 != 0 {
+		c ^= 0xf5dee51989
+	}
+	if c0&2 != 0 {
+		c ^= 0xa9fdca3312
+	}
+	if c0&4 != 0 {
+		c ^= 0x1bab10e32d
+	}
+	if c0&8 != 0 {
+		c ^= 0x3706b1677a
+	}
+	if c0&16 != 0 {
+		c ^= 0x644d626ffd
+	}
+	return c
+}
+
+// Checksum compute the 8 characters checksum of a descriptor without its checksum
+func Checksum(descriptor string) (string, error) {
+	var c uint64 = 1
+	var class, classCount int
+
+	for _, ch := range descriptor {
+		position := strings.IndexRune(inputCharset, ch)
+		if position < 0 {
+			return "", ErrInvalidDescriptorCharacter
+		}
+
+		// emit a symbol for the position inside the group, for every character
+		c = polymod(c, position&31)
+		// accumulate the group numbers
+		class = class*3 + position>>5
+		classCount++
+		if classCount == 3 {
+			// emit an extra symbol representing the group numbers, for every 3 characters
+			c = polymod(c, class)
+			class = 0
+			classCount = 0
+		}
+	}
+	if classCount > 0 {
+		c = polymod(c, class)
+	}
+	// shift further to determine the checksum
+	for i := 0; i < checksumLength; i++ {
+		c = polymod(c, 0)
+	}
+	// prevent appending zeroes from not affecting the checksum
+	c ^= 1
+
+	var checksum = make([]byte, checksumLength)
+	for i := range checksum {
+		checksum[i] = checksumCharset[(c>>(5*(7-uint(i))))&31]
+	}
+
+	return string(checksum), nil
+}
+
+// AddChecksum append #checksum to a descriptor
+func AddChecksum(descriptor string) (string, error) {
+	checksum, err := Checksum(descriptor)
+	if err != nil {
+		return "", err
+	}
+
+	return descriptor + "#" + checksum, nil
+}
+
+// SplitChecksum split a descriptor from its checksum and verify it, the checksum is mandatory when required is set
+func SplitChecksum(descriptor string, required bool) (string, error) {
+	separator := strings.LastIndex(descriptor, "#")
+	if separator < 0 {
+		if required {
+			return "", ErrMissingChecksum
+		}
+		return descriptor, nil
+	}
+
+	body, checksum := descriptor[:separator], descriptor[separator+1:]
+	expected, err := Checksum(body)
+	if err != nil {
+		return "", err
+	}
+	if checksum != expected {
+		return "", ErrInvalidChecksum
+	}
+
+	return body, nil
+}
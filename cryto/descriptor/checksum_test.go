@@ -0,0 +1,38 @@
+package descriptor
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestChecksum(t *testing.T) {
+	// BIP380 test vector
+	var checksum, err = Checksum("raw(deadbeef)")
+
+	assert.NoError(t, err, "Expected no error: valid descriptor")
+	assert.Equal(t, "89f8spxm", checksum, "Incorrect checksum")
+
+	_, err = Checksum("raw(déadbeef)")
+
+	assert.Equal(t, ErrInvalidDescriptorCharacter, err, "Expected error: character outside the descriptor charset")
+}
+
+func TestSplitChecksum(t *testing.T) {
+	var body, err = SplitChecksum("raw(deadbeef)#89f8spxm", true)
+
+	assert.NoError(t, err, "Expected no error: valid checksum")
+	assert.Equal(t, "raw(deadbeef)", body, "Incorrect descriptor")
+
+	_, err = SplitChecksum("raw(deadbeef)#89f8spxn", false)
+
+	assert.Equal(t, ErrInvalidChecksum, err, "Expected error: invalid checksum")
+
+	_, err = SplitChecksum("raw(deadbeef)", true)
+
+	assert.Equal(t, ErrMissingChecksum, err, "Expected error: missing checksum")
+
+	body, err = SplitChecksum("raw(deadbeef)", false)
+
+	assert.NoError(t, err, "Expected no error: optional checksum")
+	assert.Equal(t, "raw(deadbeef)", body, "Incorrect descriptor")
+}
@@ -0,0 +1,118 @@
+package descriptor
+
+import (
+	"encoding/hex"
+	"errors"
+
+	"btcwalletapi/cryto/multisig"
+	"btcwalletapi/cryto/segwit"
+)
+
+var ErrNotRanged = errors.New("descriptor is not ranged, only index 0 can be derived")
+
+// typePurpose the purpose whose address type a single key descriptor type stands for
+var typePurpose = map[Type]segwit.Purpose{
+	TypePKH:    segwit.PurposeBIP44,
+	TypeSHWPKH: segwit.PurposeBIP49,
+	TypeWPKH:   segwit.PurposeBIP84,
+	TypeTR:     segwit.PurposeBIP86,
+}
+
+// DerivedAddress an address of a descriptor with the public keys it commits to
+type DerivedAddress struct {
+	Index        uint32
+	Address      string
+	ScriptPubKey []byte
+	PublicKeys   [][]byte
+}
+
+// Address derive the address of a descriptor at a wildcard index, a descriptor not ranged only has index 0
+func (d *Descriptor) Address(index uint32) (*DerivedAddress, error) {
+	if index >= segwit.Apostrophe {
+		return nil, segwit.ErrIndexOutOfRange
+	}
+	if index > 0 && !d.Ranged() {
+		return nil, ErrNotRanged
+	}
+
+	var publicKeys = make([][]byte, len(d.Keys))
+	for i, key := range d.Keys {
+		publicKey, err := key.publicKeyAt(index)
+		if err != nil {
+			return nil, err
+		}
+		publicKeys[i] = publicKey
+	}
+
+	var address string
+	var err error
+	if d.Type == TypeMultisig {
+		var keys = make([]string, len(publicKeys))
+		for i, publicKey := range publicKeys {
+			keys[i] = hex.EncodeToString(publicKey)
+		}
+		var script *multisig.Script
+		script, err = multisig.GenerateScriptAddress(d.M, len(keys), keys, d.ScriptType, d.Sorted, d.Network)
+		if err == nil {
+			address = script.Address
+		}
+	} else {
+		publicKey := publicKeys[0]
+		// x-only tr() keys lift to their even y point
+		if len(publicKey) == 32 {
+			publicKey = append([]byte{0x02}, publicKey...)
+		}
+		address, err = segwit.PublicKeyAddress(typePurpose[d.Type], publicKey, d.Network)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := segwit.DecodeAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DerivedAddress{
+		Index:        index,
+		Address:      address,
+		ScriptPubKey: info.ScriptPubKey,
+		PublicKeys:   publicKeys,
+	}, nil
+}
+
+// AddressRange derive count consecutive addresses of a descriptor starting at index start,
+// the keys before the wildcard are derived once at parsing
+func (d *Descriptor) AddressRange(start, count uint32) ([]*DerivedAddress, error) {
+	if uint64(start)+uint64(count) > uint64(segwit.Apostrophe) {
+		return nil, segwit.ErrIndexOutOfRange
+	}
+
+	result := make([]*DerivedAddress, 0, count)
+	for index := start; index < start+count; index++ {
+		address, err := d.Address(index)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, address)
+	}
+
+	return result, nil
+}
+
+// publicKeyAt give the serialized public key of a key expression, at a wildcard index when ranged
+func (k *Key) publicKeyAt(index uint32) ([]byte, error) {
+	if k.Extended == nil {
+		return k.PublicKey, nil
+	}
+	if !k.Wildcard {
+		return k.parent.Key, nil
+	}
+
+	child, err := k.parent.NewChildKey(index)
+	if err != nil {
+		return nil, err
+	}
+
+	return child.Key, nil
+}
@@ -0,0 +1,91 @@
+package descriptor
+
+import (
+	"btcwalletapi/cryto/multisig"
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDescriptor_AddressRange(t *testing.T) {
+	var vectors = []struct {
+		descriptor string
+		addresses  []string
+	}{
+		{
+			"pkh([73c5da0a/44'/0'/0']" + testBIP44XPub + "/0/*)",
+			[]string{"1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA", "1Ak8PffB2meyfYnbXZR9EGfLfFZVpzJvQP"},
+		},
+		{
+			"sh(wpkh([73c5da0a/49'/0'/0']" + testBIP49XPub + "/0/*))",
+			[]string{"37VucYSaXLCAsxYyAPfbSi9eh4iEcbShgf", "3LtMnn87fqUeHBUG414p9CWwnoV6E2pNKS"},
+		},
+		{
+			"wpkh([73c5da0a/84'/0'/0']" + testBIP84XPub + "/0/*)",
+			[]string{"bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu", "bc1qnjg0jd8228aq7egyzacy8cys3knf9xvrerkf9g"},
+		},
+		{
+			"tr([73c5da0a/86'/0'/0']" + testBIP86XPub + "/0/*)",
+			[]string{"bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr", "bc1p4qhjn9zdvkux4e44uhx8tc55attvtyu358kutcqkudyccelu0was9fqzwh"},
+		},
+	}
+
+	for _, vector := range vectors {
+		descriptor, err := Parse(vector.descriptor, nil)
+
+		assert.NoError(t, err, "Expected no error: valid descriptor")
+
+		addresses, err := descriptor.AddressRange(0, 2)
+
+		assert.NoError(t, err, "Expected no error: valid range")
+		for i, address := range addresses {
+			assert.Equal(t, uint32(i), address.Index, "Incorrect index")
+			assert.Equal(t, vector.addresses[i], address.Address, "Incorrect address")
+		}
+	}
+}
+
+func TestDescriptor_Address(t *testing.T) {
+	// BIP383 test vector
+	var descriptor, err = Parse("sh(multi(2,022f01e5e15cca351daff3843fb70f3c2f0a1bdd05e5af888a67784ef3e10a2a01,03acd484e2f0c7f65309ad178a9f559abde09796974c57e714c35f110dfc27ccbe))", nil)
+
+	assert.NoError(t, err, "Expected no error: valid descriptor")
+
+	address, err := descriptor.Address(0)
+
+	assert.NoError(t, err, "Expected no error: index 0")
+	assert.Equal(t, "3GtEB3yg3r5de2cDJG48SkQwxfxJumKQdN", address.Address, "Incorrect address")
+	assert.Equal(t, "a914a6a8b030a38762f4c1f5cbe387b61a3c5da5cd2687", hex.EncodeToString(address.ScriptPubKey), "Incorrect scriptPubKey")
+
+	_, err = descriptor.Address(1)
+
+	assert.Equal(t, ErrNotRanged, err, "Expected error: descriptor not ranged")
+
+	// BIP341 x-only internal key of the abandon mnemonic m/86'/0'/0'/0/0
+	descriptor, _ = Parse("tr(cc8a4bc64d897bddc5fbc2f670f7a8ba0b386779106cf1223c6fc5d7cd6fc115)", nil)
+	address, _ = descriptor.Address(0)
+
+	assert.Equal(t, "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr", address.Address, "Incorrect taproot address")
+}
+
+func TestDescriptor_HDMultisig(t *testing.T) {
+	var cosigners = []multisig.CosignerKey{
+		{XPub: "xpub6DkFAXWQ2dHxq2vatrt9qyA3bXYU4ToWQwCHbf5XB2mSTexcHZCeKS1VZYcPoBd5X8yVcbXFHJR9R8UCVpt82VX1VhR28mCyxUFL4r6KFrf", Fingerprint: "73c5da0a", Path: "m/48'/0'/0'/2'"},
+		{XPub: "xpub6DzhyrnFFYQ1HimDiM388xHnDiRPNdZJFBmmxge3Y1WWcHLtMJLfRuhRHqnQCPbTj3fGKTuKFLHzzwpJkp5Dtc3UtLKZKaVZe1yqMBXd6Vk"},
+	}
+	var hd, _ = multisig.NewHDMultisig(2, cosigners, "", nil, nil)
+	var expected, _ = hd.AddressRange(0, 0, 3)
+
+	// the exported descriptor derives the addresses of the wallet
+	exported, _ := HDMultisig(hd, 0)
+	descriptor, err := Parse(exported, nil)
+
+	assert.NoError(t, err, "Expected no error: exported descriptor")
+
+	addresses, err := descriptor.AddressRange(0, 3)
+
+	assert.NoError(t, err, "Expected no error: valid range")
+	for i := range expected {
+		assert.Equal(t, expected[i].Script.Address, addresses[i].Address, "Incorrect multisig address")
+	}
+}
@@ -0,0 +1,47 @@
+package descriptor
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"btcwalletapi/cryto/segwit"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/tyler-smith/go-bip32"
+)
+
+// keyOrigin format a [fingerprint/path] key origin, empty without fingerprint
+func keyOrigin(fingerprint []byte, origin []uint32) string {
+	if len(fingerprint) == 0 {
+		return ""
+	}
+
+	var parts = []string{hex.EncodeToString(fingerprint)}
+	if len(origin) > 0 {
+		parts = append(parts, segwit.FormatPath(origin, true))
+	}
+
+	return "[" + strings.Join(parts, "/") + "]"
+}
+
+// extendedKey serialize an extended key with the xpub/xprv (tpub/tprv) version of the network,
+// descriptors do not use SLIP-132 versions
+func extendedKey(key *bip32.Key, network *chaincfg.Params) string {
+	var clone = *key
+	if key.IsPrivate {
+		clone.Version = network.HDPrivateKeyID[:]
+	} else {
+		clone.Version = network.HDPublicKeyID[:]
+	}
+
+	return clone.B58Serialize()
+}
+
+// rangedKey format an extended key expression deriving path then a wildcard index
+func rangedKey(origin string, key string, path []uint32) string {
+	var expression = origin + key
+	if len(path) > 0 {
+		expression += "/" + segwit.FormatPath(path, true)
+	}
+
+	return expression + "/*"
+}
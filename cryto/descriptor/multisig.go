@@ -0,0 +1,56 @@
+package descriptor
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"btcwalletapi/cryto/multisig"
+)
+
+// Multisig give the output descriptor with checksum of a multisig script,
+// sh(multi(...)), sh(wsh(multi(...))) or wsh(multi(...)), sortedmulti when the keys are sorted per BIP67
+func Multisig(script *multisig.Script) (string, error) {
+	var keys = make([]string, len(script.PublicKeys))
+	for i, publicKey := range script.PublicKeys {
+		keys[i] = hex.EncodeToString(publicKey)
+	}
+
+	return multisigDescriptor(script.Type, script.M, keys, script.Sorted)
+}
+
+// HDMultisig give the ranged output descriptor with checksum of a HD multisig wallet chain,
+// sortedmulti of every cosigner key expression [fingerprint/origin]xpub/[cosigner_index/]change/*
+func HDMultisig(hd *multisig.HDMultisig, change uint32) (string, error) {
+	var path = hd.RelativePath(change, 0)
+	path = path[:len(path)-1]
+
+	var keys = make([]string, len(hd.Cosigners))
+	for i, cosigner := range hd.Cosigners {
+		keys[i] = rangedKey(keyOrigin(cosigner.Fingerprint, cosigner.Origin), extendedKey(cosigner.Key, hd.Network), path)
+	}
+
+	return multisigDescriptor(hd.ScriptType, hd.M, keys, true)
+}
+
+// multisigDescriptor wrap a multi or sortedmulti expression of key expressions for the script type
+func multisigDescriptor(scriptType multisig.ScriptType, m int, keys []string, sorted bool) (string, error) {
+	var function = "multi"
+	if sorted {
+		function = "sortedmulti"
+	}
+	var descriptor = fmt.Sprintf("%s(%d,%s)", function, m, strings.Join(keys, ","))
+
+	switch scriptType {
+	case multisig.ScriptTypeP2SH:
+		descriptor = "sh(" + descriptor + ")"
+	case multisig.ScriptTypeP2SHP2WSH:
+		descriptor = "sh(wsh(" + descriptor + "))"
+	case multisig.ScriptTypeP2WSH:
+		descriptor = "wsh(" + descriptor + ")"
+	default:
+		return "", multisig.ErrUnsupportedScriptType
+	}
+
+	return AddChecksum(descriptor)
+}
@@ -0,0 +1,59 @@
+package descriptor
+
+import (
+	"btcwalletapi/cryto/multisig"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestMultisig(t *testing.T) {
+	// BIP383 test vector
+	var pubKeys = []string{
+		"022f01e5e15cca351daff3843fb70f3c2f0a1bdd05e5af888a67784ef3e10a2a01",
+		"03acd484e2f0c7f65309ad178a9f559abde09796974c57e714c35f110dfc27ccbe",
+	}
+	var script, err = multisig.GenerateScriptAddress(2, 2, pubKeys, multisig.ScriptTypeP2SH, false, &chaincfg.MainNetParams)
+
+	assert.NoError(t, err, "Expected no error: valid script")
+	assert.Equal(t, "3GtEB3yg3r5de2cDJG48SkQwxfxJumKQdN", script.Address, "Incorrect address")
+
+	descriptor, err := Multisig(script)
+
+	assert.NoError(t, err, "Expected no error: valid descriptor")
+	assert.True(t, strings.HasPrefix(descriptor, "sh(multi(2,"+pubKeys[0]+","+pubKeys[1]+"))#"), "Incorrect descriptor")
+
+	_, err = SplitChecksum(descriptor, true)
+
+	assert.NoError(t, err, "Expected no error: valid checksum")
+
+	script, _ = multisig.GenerateScriptAddress(2, 2, pubKeys, multisig.ScriptTypeP2WSH, true, &chaincfg.MainNetParams)
+	descriptor, _ = Multisig(script)
+
+	assert.True(t, strings.HasPrefix(descriptor, "wsh(sortedmulti(2,"), "Incorrect descriptor")
+
+	script, _ = multisig.GenerateScriptAddress(2, 2, pubKeys, multisig.ScriptTypeP2SHP2WSH, false, &chaincfg.MainNetParams)
+	descriptor, _ = Multisig(script)
+
+	assert.True(t, strings.HasPrefix(descriptor, "sh(wsh(multi(2,"), "Incorrect descriptor")
+}
+
+func TestHDMultisig(t *testing.T) {
+	var cosigners = []multisig.CosignerKey{
+		{XPub: "xpub6DkFAXWQ2dHxq2vatrt9qyA3bXYU4ToWQwCHbf5XB2mSTexcHZCeKS1VZYcPoBd5X8yVcbXFHJR9R8UCVpt82VX1VhR28mCyxUFL4r6KFrf", Fingerprint: "73c5da0a", Path: "m/48'/0'/0'/2'"},
+		{XPub: "xpub6DzhyrnFFYQ1HimDiM388xHnDiRPNdZJFBmmxge3Y1WWcHLtMJLfRuhRHqnQCPbTj3fGKTuKFLHzzwpJkp5Dtc3UtLKZKaVZe1yqMBXd6Vk"},
+	}
+	var hd, err = multisig.NewHDMultisig(2, cosigners, "", nil, nil)
+
+	assert.NoError(t, err, "Expected no error: valid cosigners")
+
+	descriptor, err := HDMultisig(hd, 1)
+
+	assert.NoError(t, err, "Expected no error: valid descriptor")
+	assert.True(t, strings.HasPrefix(descriptor, "wsh(sortedmulti(2,[73c5da0a/48'/0'/0'/2']"+cosigners[0].XPub+"/1/*,"+cosigners[1].XPub+"/1/*))#"), "Incorrect descriptor")
+
+	_, err = SplitChecksum(descriptor, true)
+
+	assert.NoError(t, err, "Expected no error: valid checksum")
+}
@@ -0,0 +1,285 @@
+package descriptor
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+
+	"btcwalletapi/cryto/multisig"
+	"btcwalletapi/cryto/segwit"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/tyler-smith/go-bip32"
+)
+
+// Type output type of a descriptor
+type Type string
+
+const (
+	TypePKH      Type = "pkh"
+	TypeSHWPKH   Type = "sh(wpkh)"
+	TypeWPKH     Type = "wpkh"
+	TypeTR       Type = "tr"
+	TypeMultisig Type = "multisig"
+)
+
+// wildcard last step of a ranged key expression
+const wildcard = "*"
+
+var (
+	ErrUnsupportedDescriptor = errors.New("unsupported descriptor, expected pkh, sh(wpkh), wpkh, tr, sh(multi), sh(wsh(multi)) or wsh(multi) with sortedmulti variants")
+	ErrInvalidKeyOrigin      = errors.New("invalid key origin, expected [fingerprint/path]")
+	ErrInvalidKeyExpression  = errors.New("invalid key expression")
+	ErrPrivateKey            = errors.New("private keys are not accepted, use xpub/tpub or public keys")
+	ErrHardenedWildcard      = errors.New("hardened wildcard derivation is not possible from a public key")
+	ErrUncompressedKey       = errors.New("segwit descriptors only accept compressed public keys")
+	ErrNetworkMixed          = errors.New("descriptor extended keys belong to different networks")
+	ErrThreshold             = errors.New("invalid multisig threshold")
+)
+
+// Key a key expression, a raw public key or an extended public key with its derivation steps and
+// an optional wildcard, with the optional origin of the key
+type Key struct {
+	Fingerprint []byte
+	Origin      []uint32
+	// PublicKey serialized public key, x-only in tr() descriptors, nil for extended keys
+	PublicKey []byte
+	// Extended, Path and Wildcard of extended keys, Path being derived before the wildcard index
+	Extended *bip32.Key
+	Path     []uint32
+	Wildcard bool
+	// parent key the wildcard index derives from, derived once
+	parent *bip32.Key
+}
+
+// Descriptor a parsed output descriptor
+type Descriptor struct {
+	Type Type
+	// ScriptType, M and Sorted of multisig descriptors
+	ScriptType multisig.ScriptType
+	M          int
+	Sorted     bool
+	Keys       []*Key
+	Network    *chaincfg.Params
+	body       string
+}
+
+// Parse parse an output descriptor, its checksum being verified when present, network nil selecting the network
+// of the extended keys, mainnet without any
+func Parse(descriptor string, network *chaincfg.Params) (*Descriptor, error) {
+	body, err := SplitChecksum(strings.TrimSpace(descriptor), false)
+	if err != nil {
+		return nil, err
+	}
+
+	var result = &Descriptor{body: body}
+	var keys []string
+	switch {
+	case unwrap(&body, "sh"):
+		switch {
+		case unwrap(&body, "wpkh"):
+			result.Type, keys = TypeSHWPKH, []string{body}
+		case unwrap(&body, "wsh"):
+			result.Type, result.ScriptType = TypeMultisig, multisig.ScriptTypeP2SHP2WSH
+		default:
+			result.Type, result.ScriptType = TypeMultisig, multisig.ScriptTypeP2SH
+		}
+	case unwrap(&body, "wsh"):
+		result.Type, result.ScriptType = TypeMultisig, multisig.ScriptTypeP2WSH
+	case unwrap(&body, "pkh"):
+		result.Type, keys = TypePKH, []string{body}
+	case unwrap(&body, "wpkh"):
+		result.Type, keys = TypeWPKH, []string{body}
+	case unwrap(&body, "tr"):
+		// key path only, script trees are not supported
+		if strings.Contains(body, ",") {
+			return nil, ErrUnsupportedDescriptor
+		}
+		result.Type, keys = TypeTR, []string{body}
+	default:
+		return nil, ErrUnsupportedDescriptor
+	}
+
+	if result.Type == TypeMultisig {
+		switch {
+		case unwrap(&body, "sortedmulti"):
+			result.Sorted = true
+		case unwrap(&body, "multi"):
+		default:
+			return nil, ErrUnsupportedDescriptor
+		}
+		arguments := strings.Split(body, ",")
+		result.M, err = strconv.Atoi(arguments[0])
+		if err != nil || len(arguments) < 2 || result.M < 1 || result.M > len(arguments)-1 {
+			return nil, ErrThreshold
+		}
+		keys = arguments[1:]
+	}
+
+	var mainNet *bool
+	for _, expression := range keys {
+		key, err := parseKey(expression, result.uncompressedKeys(), result.Type == TypeTR)
+		if err != nil {
+			return nil, err
+		}
+		if key.Extended != nil {
+			keyMainNet := bytes.Equal(key.Extended.Version, chaincfg.MainNetParams.HDPublicKeyID[:])
+			if mainNet != nil && *mainNet != keyMainNet {
+				return nil, ErrNetworkMixed
+			}
+			mainNet = &keyMainNet
+		}
+		result.Keys = append(result.Keys, key)
+	}
+
+	// resolve network from the extended keys
+	switch {
+	case mainNet == nil && network == nil:
+		network = &chaincfg.MainNetParams
+	case mainNet != nil && network == nil:
+		network = &chaincfg.TestNet3Params
+		if *mainNet {
+			network = &chaincfg.MainNetParams
+		}
+	case mainNet != nil && (network.Net == chaincfg.MainNetParams.Net) != *mainNet:
+		return nil, segwit.ErrNetworkMismatch
+	}
+	result.Network = network
+
+	// derive the first address to reject key sets the script construction refuses
+	if _, err := result.Address(0); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// unwrap strip a function call from an expression, false leaves the expression as is
+func unwrap(expression *string, function string) bool {
+	if !strings.HasPrefix(*expression, function+"(") || !strings.HasSuffix(*expression, ")") {
+		return false
+	}
+
+	*expression = (*expression)[len(function)+1 : len(*expression)-1]
+	return true
+}
+
+// uncompressedKeys tell whether raw public keys may be uncompressed, only outside segwit
+func (d *Descriptor) uncompressedKeys() bool {
+	return d.Type == TypePKH || (d.Type == TypeMultisig && d.ScriptType == multisig.ScriptTypeP2SH)
+}
+
+// parseKey parse a [fingerprint/origin]KEY/path/* key expression, xOnly accepting 32 bytes tr() public keys
+func parseKey(expression string, uncompressed, xOnly bool) (*Key, error) {
+	var key = &Key{}
+
+	// key origin
+	if strings.HasPrefix(expression, "[") {
+		end := strings.Index(expression, "]")
+		if end < 0 {
+			return nil, ErrInvalidKeyOrigin
+		}
+		origin := strings.Split(expression[1:end], "/")
+		fingerprint, err := hex.DecodeString(origin[0])
+		if err != nil || len(fingerprint) != 4 {
+			return nil, ErrInvalidKeyOrigin
+		}
+		key.Fingerprint = fingerprint
+		if len(origin) > 1 {
+			key.Origin, _, err = segwit.ParsePath(strings.Join(origin[1:], "/"))
+			if err != nil {
+				return nil, ErrInvalidKeyOrigin
+			}
+		}
+		expression = expression[end+1:]
+	}
+
+	steps := strings.Split(expression, "/")
+	if decoded, err := hex.DecodeString(steps[0]); err == nil {
+		// raw public key
+		if len(steps) > 1 {
+			return nil, ErrInvalidKeyExpression
+		}
+		if err := checkPublicKey(decoded, uncompressed, xOnly); err != nil {
+			return nil, err
+		}
+		key.PublicKey = decoded
+		return key, nil
+	}
+
+	extended, err := bip32.B58Deserialize(steps[0])
+	if err != nil {
+		return nil, ErrInvalidKeyExpression
+	}
+	if extended.IsPrivate {
+		return nil, ErrPrivateKey
+	}
+	if !bytes.Equal(extended.Version, chaincfg.MainNetParams.HDPublicKeyID[:]) &&
+		!bytes.Equal(extended.Version, chaincfg.TestNet3Params.HDPublicKeyID[:]) {
+		return nil, segwit.ErrUnsupportedKeyVersion
+	}
+	key.Extended = extended
+
+	// derivation steps, then the wildcard
+	steps = steps[1:]
+	if len(steps) > 0 {
+		switch last := steps[len(steps)-1]; last {
+		case wildcard:
+			key.Wildcard = true
+			steps = steps[:len(steps)-1]
+		case wildcard + "'", wildcard + "h", wildcard + "H":
+			return nil, ErrHardenedWildcard
+		}
+	}
+	if len(steps) > 0 {
+		key.Path, _, err = segwit.ParsePath(strings.Join(steps, "/"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	key.parent = extended
+	for _, step := range key.Path {
+		if step >= segwit.Apostrophe {
+			return nil, segwit.ErrHardenedPublicDerivation
+		}
+		if key.parent, err = key.parent.NewChildKey(step); err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
+
+// checkPublicKey check a raw public key is valid and has an allowed serialization
+func checkPublicKey(publicKey []byte, uncompressed, xOnly bool) error {
+	if len(publicKey) == 32 && xOnly {
+		publicKey = append([]byte{0x02}, publicKey...)
+	}
+	if _, err := btcec.ParsePubKey(publicKey, btcec.S256()); err != nil {
+		return ErrInvalidKeyExpression
+	}
+	if len(publicKey) != btcec.PubKeyBytesLenCompressed && !uncompressed {
+		return ErrUncompressedKey
+	}
+
+	return nil
+}
+
+// Ranged tell whether a key expression of the descriptor ends with a wildcard
+func (d *Descriptor) Ranged() bool {
+	for _, key := range d.Keys {
+		if key.Wildcard {
+			return true
+		}
+	}
+	return false
+}
+
+// String give the descriptor with its checksum
+func (d *Descriptor) String() string {
+	descriptor, _ := AddChecksum(d.body)
+	return descriptor
+}
@@ -0,0 +1,76 @@
+package descriptor
+
+import (
+	"btcwalletapi/cryto/multisig"
+	"btcwalletapi/cryto/segwit"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// account extended public keys of the "abandon ... about" mnemonic
+const (
+	testBIP44XPub = "xpub6BosfCnifzxcFwrSzQiqu2DBVTshkCXacvNsWGYJVVhhawA7d4R5WSWGFNbi8Aw6ZRc1brxMyWMzG3DSSSSoekkudhUd9yLb6qx39T9nMdj"
+	testBIP49XPub = "xpub6C6nQwHaWbSrzs5tZ1q7m5R9cPK9eYpNMFesiXsYrgc1P8bvLLAet9JfHjYXKjToD8cBRswJXXbbFpXgwsswVPAZzKMa1jUp2kVkGVUaJa7"
+	testBIP84XPub = "xpub6CatWdiZiodmUeTDp8LT5or8nmbKNcuyvz7WyksVFkKB4RHwCD3XyuvPEbvqAQY3rAPshWcMLoP2fMFMKHPJ4ZeZXYVUhLv1VMrjPC7PW6V"
+	testBIP86XPub = "xpub6BgBgsespWvERF3LHQu6CnqdvfEvtMcQjYrcRzx53QJjSxarj2afYWcLteoGVky7D3UKDP9QyrLprQ3VCECoY49yfdDEHGCtMMj92pReUsQ"
+)
+
+func TestParse(t *testing.T) {
+	var descriptor, err = Parse("wpkh([73c5da0a/84h/0h/0h]"+testBIP84XPub+"/0/*)#afwvtk2s", nil)
+
+	assert.NoError(t, err, "Expected no error: valid descriptor")
+	assert.Equal(t, TypeWPKH, descriptor.Type, "Incorrect type")
+	assert.True(t, descriptor.Ranged(), "Expected a ranged descriptor")
+	assert.Equal(t, &chaincfg.MainNetParams, descriptor.Network, "Expected the network of the xpub")
+	assert.Equal(t, []byte{0x73, 0xc5, 0xda, 0x0a}, descriptor.Keys[0].Fingerprint, "Incorrect fingerprint")
+	assert.Equal(t, []uint32{segwit.PurposeBIP84, segwit.Apostrophe, segwit.Apostrophe}, descriptor.Keys[0].Origin, "Incorrect origin")
+	assert.Equal(t, []uint32{0}, descriptor.Keys[0].Path, "Incorrect path")
+	assert.Equal(t, "wpkh([73c5da0a/84h/0h/0h]"+testBIP84XPub+"/0/*)#afwvtk2s", descriptor.String(), "Incorrect descriptor")
+
+	descriptor, err = Parse("wsh(sortedmulti(1,[73c5da0a/48'/0'/0'/2']"+testBIP84XPub+"/1/*,"+testBIP86XPub+"/1/*))", nil)
+
+	assert.NoError(t, err, "Expected no error: valid multisig descriptor")
+	assert.Equal(t, TypeMultisig, descriptor.Type, "Incorrect type")
+	assert.Equal(t, multisig.ScriptTypeP2WSH, descriptor.ScriptType, "Incorrect script type")
+	assert.Equal(t, 1, descriptor.M, "Incorrect threshold")
+	assert.True(t, descriptor.Sorted, "Expected sorted keys")
+	assert.Len(t, descriptor.Keys, 2, "Incorrect keys")
+
+	descriptor, err = Parse("sh(wsh(multi(1,022f01e5e15cca351daff3843fb70f3c2f0a1bdd05e5af888a67784ef3e10a2a01)))", &chaincfg.TestNet3Params)
+
+	assert.NoError(t, err, "Expected no error: valid raw key descriptor")
+	assert.Equal(t, multisig.ScriptTypeP2SHP2WSH, descriptor.ScriptType, "Incorrect script type")
+	assert.False(t, descriptor.Ranged(), "Expected a descriptor not ranged")
+	assert.Equal(t, &chaincfg.TestNet3Params, descriptor.Network, "Expected the requested network")
+}
+
+func TestParse_Errors(t *testing.T) {
+	var vectors = []struct {
+		descriptor string
+		err        error
+	}{
+		{"wpkh(" + testBIP84XPub + "/0/*)#afwvtk2s", ErrInvalidChecksum},
+		{"combo(" + testBIP84XPub + "/0/*)", ErrUnsupportedDescriptor},
+		{"tr(" + testBIP86XPub + "/0/*,pk(" + testBIP84XPub + "/0/*))", ErrUnsupportedDescriptor},
+		{"wsh(thresh(1," + testBIP84XPub + "/0/*))", ErrUnsupportedDescriptor},
+		{"wsh(multi(3," + testBIP84XPub + "/0/*," + testBIP86XPub + "/0/*))", ErrThreshold},
+		{"wpkh([73c5da0a84h/0h/0h]" + testBIP84XPub + "/0/*)", ErrInvalidKeyOrigin},
+		{"wpkh(" + testBIP84XPub + "/0h/*)", segwit.ErrHardenedPublicDerivation},
+		{"wpkh(" + testBIP84XPub + "/0/*')", ErrHardenedWildcard},
+		{"wpkh(xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi)", ErrPrivateKey},
+		{"wpkh(04a34b99f22c790c4e36b2b3c2c35a36db06226e41c692fc82b8b56ac1c540c5bd5b8dec5235a0fa8722476c7709c02559e3aa73aa03918ba2d492eea75abea235)", ErrUncompressedKey},
+		{"wpkh(02deadbeef)", ErrInvalidKeyExpression},
+		{"wsh(multi(1," + testBIP84XPub + "/0/*,tpubD6NzVbkrYhZ4WaWSyoBvQwbpLkojyoTZPRsgXELWz3Popb3qkjcJyJUGLnL4qHHoQvao8ESaAstxYSnhyswJ76uZPStJRJCTKvosUCJZL5B/0/*))", ErrNetworkMixed},
+	}
+
+	for _, vector := range vectors {
+		_, err := Parse(vector.descriptor, nil)
+
+		assert.Equal(t, vector.err, err, "Unexpected error: "+vector.descriptor)
+	}
+
+	_, err := Parse("wpkh("+testBIP84XPub+"/0/*)", &chaincfg.TestNet3Params)
+
+	assert.Equal(t, segwit.ErrNetworkMismatch, err, "Expected error: mainnet key on testnet")
+}
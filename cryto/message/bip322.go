@@ -0,0 +1,196 @@
+package message
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+
+	"btcwalletapi/cryto/taproot"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+// BIP322Hash give the tagged hash of a message the virtual to_spend transaction commits to
+func BIP322Hash(msg string) []byte {
+	return taproot.TaggedHash("BIP0322-signed-message", []byte(msg))
+}
+
+// toSpend build the virtual transaction paying the message challenge, its input spends no real output
+// and its scriptSig commits to the message
+func toSpend(scriptPubKey []byte, msg string) *wire.MsgTx {
+	scriptSig := append([]byte{txscript.OP_0, txscript.OP_DATA_32}, BIP322Hash(msg)...)
+
+	tx := wire.NewMsgTx(0)
+	txIn := wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{}, wire.MaxPrevOutIndex), scriptSig, nil)
+	txIn.Sequence = 0
+	tx.AddTxIn(txIn)
+	tx.AddTxOut(wire.NewTxOut(0, scriptPubKey))
+
+	return tx
+}
+
+// toSign build the virtual transaction spending the to_spend output to an OP_RETURN output,
+// its witness being the signature
+func toSign(spend *wire.MsgTx, witness wire.TxWitness) *wire.MsgTx {
+	spendHash := spend.TxHash()
+
+	tx := wire.NewMsgTx(0)
+	txIn := wire.NewTxIn(wire.NewOutPoint(&spendHash, 0), nil, witness)
+	txIn.Sequence = 0
+	tx.AddTxIn(txIn)
+	tx.AddTxOut(wire.NewTxOut(0, []byte{txscript.OP_RETURN}))
+
+	return tx
+}
+
+// SignBIP322 give the base64 BIP322 simple signature of a message for a P2WPKH or P2TR scriptPubKey, P2WPKH being
+// signed with SIGHASH_ALL by the publicKey serialization it hashes, P2TR on its BIP86 key path with SIGHASH_DEFAULT
+func SignBIP322(privateKey *btcec.PrivateKey, publicKey, scriptPubKey []byte, msg string) (string, error) {
+	spend := toSpend(scriptPubKey, msg)
+	tx := toSign(spend, nil)
+
+	var witness wire.TxWitness
+	switch txscript.GetScriptClass(scriptPubKey) {
+	case txscript.WitnessV0PubKeyHashTy:
+		if !bytes.Equal(btcutil.Hash160(publicKey), scriptPubKey[2:]) {
+			return "", ErrSignatureMismatch
+		}
+		signature, err := txscript.RawTxInWitnessSignature(tx, txscript.NewTxSigHashes(tx), 0, 0, scriptPubKey, txscript.SigHashAll, privateKey)
+		if err != nil {
+			return "", err
+		}
+		witness = wire.TxWitness{signature, publicKey}
+
+	default:
+		if !isTaproot(scriptPubKey) {
+			return "", ErrUnsupportedAddress
+		}
+		tweaked, err := taproot.TweakPrivateKey(privateKey, nil)
+		if err != nil {
+			return "", err
+		}
+		if !bytes.Equal(taproot.XOnly(tweaked.PubKey()), scriptPubKey[2:]) {
+			return "", ErrSignatureMismatch
+		}
+		sigHash, err := taproot.SignatureHash(tx, 0, spend.TxOut, taproot.SigHashDefault)
+		if err != nil {
+			return "", err
+		}
+		var aux [32]byte
+		if _, err := rand.Read(aux[:]); err != nil {
+			return "", err
+		}
+		signature, err := taproot.Sign(tweaked, sigHash, aux[:])
+		if err != nil {
+			return "", err
+		}
+		witness = wire.TxWitness{signature}
+	}
+
+	return encodeWitness(witness)
+}
+
+// VerifyBIP322 check a base64 BIP322 simple signature of a message against a P2WPKH or P2TR scriptPubKey
+func VerifyBIP322(scriptPubKey []byte, msg, signature string) error {
+	witness, err := decodeWitness(signature)
+	if err != nil {
+		return err
+	}
+
+	spend := toSpend(scriptPubKey, msg)
+	tx := toSign(spend, witness)
+
+	switch txscript.GetScriptClass(scriptPubKey) {
+	case txscript.WitnessV0PubKeyHashTy:
+		if len(witness) != 2 || len(witness[0]) == 0 {
+			return ErrInvalidSignature
+		}
+		publicKey, err := btcec.ParsePubKey(witness[1], btcec.S256())
+		if err != nil || !bytes.Equal(btcutil.Hash160(witness[1]), scriptPubKey[2:]) {
+			return ErrSignatureMismatch
+		}
+		der, hashType := witness[0][:len(witness[0])-1], txscript.SigHashType(witness[0][len(witness[0])-1])
+		ecdsaSignature, err := btcec.ParseDERSignature(der, btcec.S256())
+		if err != nil {
+			return ErrInvalidSignature
+		}
+		sigHash, err := txscript.CalcWitnessSigHash(scriptPubKey, txscript.NewTxSigHashes(tx), hashType, tx, 0, 0)
+		if err != nil || !ecdsaSignature.Verify(sigHash, publicKey) {
+			return ErrSignatureMismatch
+		}
+
+	default:
+		if !isTaproot(scriptPubKey) {
+			return ErrUnsupportedAddress
+		}
+		if len(witness) != 1 {
+			return ErrInvalidSignature
+		}
+		// a 65 bytes signature carries an explicit sighash type, never SIGHASH_DEFAULT
+		var schnorrSignature, hashType = witness[0], taproot.SigHashDefault
+		switch len(schnorrSignature) {
+		case 64:
+		case 65:
+			schnorrSignature, hashType = schnorrSignature[:64], txscript.SigHashType(schnorrSignature[64])
+			if hashType == taproot.SigHashDefault {
+				return ErrInvalidSignature
+			}
+		default:
+			return ErrInvalidSignature
+		}
+		sigHash, err := taproot.SignatureHash(tx, 0, spend.TxOut, hashType)
+		if err != nil || !taproot.Verify(scriptPubKey[2:], sigHash, schnorrSignature) {
+			return ErrSignatureMismatch
+		}
+	}
+
+	return nil
+}
+
+// isTaproot tell whether a scriptPubKey is a segwit v1 32 bytes witness program
+func isTaproot(scriptPubKey []byte) bool {
+	return len(scriptPubKey) == 34 && scriptPubKey[0] == txscript.OP_1 && scriptPubKey[1] == txscript.OP_DATA_32
+}
+
+// encodeWitness give the base64 consensus serialization of a witness stack
+func encodeWitness(witness wire.TxWitness) (string, error) {
+	var buf bytes.Buffer
+	if err := wire.WriteVarInt(&buf, 0, uint64(len(witness))); err != nil {
+		return "", err
+	}
+	for _, item := range witness {
+		if err := wire.WriteVarBytes(&buf, 0, item); err != nil {
+			return "", err
+		}
+	}
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// decodeWitness parse a base64 consensus serialized witness stack, trailing data is rejected
+func decodeWitness(encoded string) (wire.TxWitness, error) {
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+
+	r := bytes.NewReader(decoded)
+	count, err := wire.ReadVarInt(r, 0)
+	if err != nil || count > uint64(len(decoded)) {
+		return nil, ErrInvalidSignature
+	}
+	var witness = make(wire.TxWitness, count)
+	for i := range witness {
+		if witness[i], err = wire.ReadVarBytes(r, 0, wire.MaxMessagePayload, "witness item"); err != nil {
+			return nil, ErrInvalidSignature
+		}
+	}
+	if r.Len() != 0 {
+		return nil, ErrInvalidSignature
+	}
+
+	return witness, nil
+}
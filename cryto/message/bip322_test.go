@@ -0,0 +1,74 @@
+package message
+
+import (
+	"encoding/hex"
+	"github.com/btcsuite/btcutil"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// BIP322 test vector key and addresses
+const (
+	testWIF           = "L3VFeEujGtevx9w18HD1fhRbCH67Az2dpCymeRE1SoPK6XQtaN2k"
+	testP2WPKHScript  = "00142b05d564e6a7a33c087f16e0f730d1440123799d"
+	testP2TRScript    = "51200b34f2cc6f60d54e3fdc2d1dd053fcc393bd2db9acc8de4a7c3cc28a83d4d8e9"
+	testEmptySig      = "AkcwRAIgM2gBAQqvZX15ZiysmKmQpDrG83avLIT492QBzLnQIxYCIBaTpOaD20qRlEylyxFSeEA2ba9YOixpX8z46TSDtS40ASECx/EgAxlkQpQ9hYjgGu6EBCPMVPwVIVJqO4XCsMvViHI="
+	testHelloWorldSig = "AkcwRAIgZRfIY3p7/DoVTty6YZbWS71bc5Vct9p9Fia83eRmw2QCICK/ENGfwLtptFluMGs2KsqoNSk89pO7F29zJLUx9a/sASECx/EgAxlkQpQ9hYjgGu6EBCPMVPwVIVJqO4XCsMvViHI="
+)
+
+func TestBIP322Hash(t *testing.T) {
+	assert.Equal(t, "c90c269c4f8fcbe6880f72a721ddfbf1914268a794cbb21cfafee13770ae19f1", hex.EncodeToString(BIP322Hash("")), "Incorrect empty message hash")
+	assert.Equal(t, "f0eb03b1a75ac6d9847f55c624a99169b5dccba2a31f5b23bea77ba270de0a7a", hex.EncodeToString(BIP322Hash("Hello World")), "Incorrect message hash")
+}
+
+func TestToSpend(t *testing.T) {
+	var scriptPubKey, _ = hex.DecodeString(testP2WPKHScript)
+
+	spend := toSpend(scriptPubKey, "")
+	sign := toSign(spend, nil)
+
+	assert.Equal(t, "c5680aa69bb8d860bf82d4e9cd3504b55dde018de765a91bb566283c545a99a7", spend.TxHash().String(), "Incorrect to_spend txid")
+	assert.Equal(t, "1e9654e951a5ba44c8604c4de6c67fd78a27e81dcadcfe1edf638ba3aaebaed6", sign.TxHash().String(), "Incorrect to_sign txid")
+}
+
+func TestSignBIP322(t *testing.T) {
+	var wif, _ = btcutil.DecodeWIF(testWIF)
+	var scriptPubKey, _ = hex.DecodeString(testP2WPKHScript)
+	var publicKey = wif.PrivKey.PubKey().SerializeCompressed()
+
+	// RFC6979 signatures without the low R grinding of the vectors, so checked by verification
+	signature, err := SignBIP322(wif.PrivKey, publicKey, scriptPubKey, "Hello World")
+
+	assert.NoError(t, err, "Expected no error: key owning the address")
+	assert.Equal(t, "Ak", signature[:2], "Expected a two items witness")
+	assert.NoError(t, VerifyBIP322(scriptPubKey, "Hello World", signature), "Expected the signature to verify")
+
+	taprootScript, _ := hex.DecodeString(testP2TRScript)
+	signature, err = SignBIP322(wif.PrivKey, publicKey, taprootScript, "Hello World")
+
+	assert.NoError(t, err, "Expected no error: key owning the taproot address")
+	assert.NoError(t, VerifyBIP322(taprootScript, "Hello World", signature), "Expected the taproot signature to verify")
+
+	_, err = SignBIP322(wif.PrivKey, publicKey[1:], scriptPubKey, "")
+
+	assert.Equal(t, ErrSignatureMismatch, err, "Expected error: key not owning the address")
+}
+
+func TestVerifyBIP322(t *testing.T) {
+	var scriptPubKey, _ = hex.DecodeString(testP2WPKHScript)
+	var taprootScript, _ = hex.DecodeString(testP2TRScript)
+
+	assert.NoError(t, VerifyBIP322(scriptPubKey, "", testEmptySig), "Expected the empty message signature to verify")
+	assert.NoError(t, VerifyBIP322(scriptPubKey, "Hello World", testHelloWorldSig), "Expected the signature to verify")
+
+	// taproot vector, SIGHASH_ALL 65 bytes signature
+	var taprootSig = "AUHd69PrJQEv+oKTfZ8l+WROBHuy9HKrbFCJu7U1iK2iiEy1vMU5EfMtjc+VSHM7aU0SDbak5IUZRVno2P5mjSafAQ=="
+
+	assert.NoError(t, VerifyBIP322(taprootScript, "Hello World", taprootSig), "Expected the taproot signature to verify")
+
+	assert.Equal(t, ErrSignatureMismatch, VerifyBIP322(scriptPubKey, "Hello World", testEmptySig), "Expected error: other message")
+	assert.Equal(t, ErrSignatureMismatch, VerifyBIP322(taprootScript, "", taprootSig), "Expected error: other message")
+	assert.Equal(t, ErrInvalidSignature, VerifyBIP322(scriptPubKey, "", "not base64!"), "Expected error: not base64")
+	assert.Equal(t, ErrInvalidSignature, VerifyBIP322(scriptPubKey, "", taprootSig), "Expected error: one item witness")
+	assert.Equal(t, ErrUnsupportedAddress, VerifyBIP322([]byte{0x6a}, "", testEmptySig), "Expected error: not P2WPKH or P2TR")
+}
@@ -0,0 +1,132 @@
+package message
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+
+	"btcwalletapi/cryto/segwit"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+// Format message signature format
+type Format string
+
+const (
+	// FormatLegacy Bitcoin Core signmessage 65 bytes compact signature, P2PKH addresses only
+	FormatLegacy Format = "legacy"
+	// FormatBIP322 BIP322 simple signature, the witness of the virtual to_sign transaction
+	FormatBIP322 Format = "bip322"
+)
+
+// magic prefix of a legacy signed message
+const magic = "Bitcoin Signed Message:\n"
+
+var (
+	ErrUnsupportedAddress = errors.New("only P2PKH, P2WPKH and P2TR addresses can sign messages")
+	ErrInvalidSignature   = errors.New("invalid message signature encoding")
+	ErrSignatureMismatch  = errors.New("signature does not match the address and message")
+)
+
+// Signature a message signature with the address it proves the ownership of
+type Signature struct {
+	Address   string
+	Format    Format
+	Signature string
+}
+
+// Sign sign a message for the address the key stands for under a purpose, with the legacy format for BIP44 P2PKH
+// addresses and the BIP322 simple format for BIP84 P2WPKH and BIP86 P2TR addresses
+func Sign(key *segwit.Key, purpose segwit.Purpose, msg string) (*Signature, error) {
+	address, publicKey, err := key.AddressKey(purpose)
+	if err != nil {
+		return nil, err
+	}
+
+	var result = &Signature{Address: address}
+	switch purpose {
+	case segwit.PurposeBIP44:
+		result.Format = FormatLegacy
+		result.Signature, err = SignLegacy(key.PrivateKey(), len(publicKey) == btcec.PubKeyBytesLenCompressed, msg)
+	case segwit.PurposeBIP84, segwit.PurposeBIP86:
+		var info *segwit.AddressInfo
+		if info, err = segwit.DecodeAddress(address); err != nil {
+			return nil, err
+		}
+		result.Format = FormatBIP322
+		result.Signature, err = SignBIP322(key.PrivateKey(), publicKey, info.ScriptPubKey, msg)
+	default:
+		return nil, ErrUnsupportedAddress
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Verify check a legacy or BIP322 simple signature of a message against an address, the format is told by the
+// address type, ErrSignatureMismatch is returned when the signature is well formed but does not match
+func Verify(address, msg, signature string) (Format, error) {
+	info, err := segwit.DecodeAddress(address)
+	if err != nil {
+		return "", err
+	}
+
+	switch info.Type {
+	case segwit.AddressTypeP2PKH:
+		return FormatLegacy, VerifyLegacy(info.ScriptPubKey, msg, signature)
+	case segwit.AddressTypeP2WPKH, segwit.AddressTypeP2TR:
+		return FormatBIP322, VerifyBIP322(info.ScriptPubKey, msg, signature)
+	default:
+		return "", ErrUnsupportedAddress
+	}
+}
+
+// Hash give the double sha256 of the magic prefixed message legacy signatures commit to
+func Hash(msg string) []byte {
+	var buf bytes.Buffer
+	wire.WriteVarString(&buf, 0, magic)
+	wire.WriteVarString(&buf, 0, msg)
+
+	return chainhash.DoubleHashB(buf.Bytes())
+}
+
+// SignLegacy give the base64 compact signature of a message, compressed telling the serialization of the
+// public key the P2PKH address hashes
+func SignLegacy(privateKey *btcec.PrivateKey, compressed bool, msg string) (string, error) {
+	signature, err := btcec.SignCompact(btcec.S256(), privateKey, Hash(msg), compressed)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+// VerifyLegacy recover the public key of a base64 compact signature and check it hashes to a P2PKH scriptPubKey
+func VerifyLegacy(scriptPubKey []byte, msg, signature string) error {
+	decoded, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil || len(decoded) != 65 {
+		return ErrInvalidSignature
+	}
+
+	publicKey, compressed, err := btcec.RecoverCompact(btcec.S256(), decoded, Hash(msg))
+	if err != nil {
+		return ErrSignatureMismatch
+	}
+
+	var serialized = publicKey.SerializeUncompressed()
+	if compressed {
+		serialized = publicKey.SerializeCompressed()
+	}
+	if txscript.GetScriptClass(scriptPubKey) != txscript.PubKeyHashTy ||
+		!bytes.Equal(btcutil.Hash160(serialized), scriptPubKey[3:23]) {
+		return ErrSignatureMismatch
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package message
+
+import (
+	"btcwalletapi/cryto/mnemonic"
+	"btcwalletapi/cryto/segwit"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newTestKey(t *testing.T, purpose segwit.Purpose) *segwit.Key {
+	seed, err := mnemonic.ToSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "")
+	assert.NoError(t, err, "Expected no error: valid mnemonic")
+	km, err := segwit.NewKeyManager(seed, &chaincfg.MainNetParams)
+	assert.NoError(t, err, "Expected no error: valid seed")
+	key, err := km.GetKey(purpose, segwit.Apostrophe, segwit.Apostrophe, 0, 0)
+	assert.NoError(t, err, "Expected no error: valid path")
+
+	return key
+}
+
+func TestHash(t *testing.T) {
+	// the magic prefix and the message are both length prefixed
+	assert.Len(t, Hash(""), 32, "Incorrect hash size")
+	assert.NotEqual(t, Hash(""), Hash("a"), "Expected the message committed to")
+}
+
+func TestSign(t *testing.T) {
+	var vectors = []struct {
+		purpose segwit.Purpose
+		format  Format
+	}{
+		{segwit.PurposeBIP44, FormatLegacy},
+		{segwit.PurposeBIP84, FormatBIP322},
+		{segwit.PurposeBIP86, FormatBIP322},
+	}
+
+	for _, vector := range vectors {
+		signature, err := Sign(newTestKey(t, vector.purpose), vector.purpose, "Hello World")
+
+		assert.NoError(t, err, "Expected no error: supported purpose")
+		assert.Equal(t, vector.format, signature.Format, "Incorrect format")
+
+		format, err := Verify(signature.Address, "Hello World", signature.Signature)
+
+		assert.NoError(t, err, "Expected the signature to verify")
+		assert.Equal(t, vector.format, format, "Incorrect verified format")
+
+		_, err = Verify(signature.Address, "Hello World!", signature.Signature)
+
+		assert.Equal(t, ErrSignatureMismatch, err, "Expected error: other message")
+	}
+
+	_, err := Sign(newTestKey(t, segwit.PurposeBIP49), segwit.PurposeBIP49, "Hello World")
+
+	assert.Equal(t, ErrUnsupportedAddress, err, "Expected error: P2SH-P2WPKH address")
+}
+
+func TestVerify(t *testing.T) {
+	var legacy, _ = Sign(newTestKey(t, segwit.PurposeBIP44), segwit.PurposeBIP44, "Hello World")
+	var native, _ = Sign(newTestKey(t, segwit.PurposeBIP84), segwit.PurposeBIP84, "Hello World")
+
+	_, err := Verify(native.Address, "Hello World", legacy.Signature)
+
+	assert.Equal(t, ErrInvalidSignature, err, "Expected error: legacy signature for a P2WPKH address")
+
+	_, err = Verify(legacy.Address, "Hello World", native.Signature)
+
+	assert.Equal(t, ErrInvalidSignature, err, "Expected error: BIP322 signature for a P2PKH address")
+
+	_, err = Verify("3JvL6Ymt8MVWiCNHC7oWU6nLeHNJKLZGLN", "Hello World", legacy.Signature)
+
+	assert.Equal(t, ErrUnsupportedAddress, err, "Expected error: P2SH address")
+
+	_, err = Verify("not an address", "Hello World", legacy.Signature)
+
+	assert.Error(t, err, "Expected error: invalid address")
+}
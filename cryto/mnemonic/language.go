@@ -0,0 +1,62 @@
+package mnemonic
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39/wordlists"
+)
+
+const (
+	LanguageEnglish            = "english"
+	LanguageJapanese           = "japanese"
+	LanguageSpanish            = "spanish"
+	LanguageFrench             = "french"
+	LanguageItalian            = "italian"
+	LanguageKorean             = "korean"
+	LanguageChineseSimplified  = "chinese_simplified"
+	LanguageChineseTraditional = "chinese_traditional"
+	LanguageCzech              = "czech"
+	LanguagePortuguese         = "portuguese"
+)
+
+var ErrUnsupportedLanguage = errors.New("unsupported mnemonic language")
+
+// ideographicSpace word separator of japanese mnemonics
+const ideographicSpace = "\u3000"
+
+// wordlistsByLanguage official BIP39 wordlists, portuguese is not shipped by go-bip39 yet
+var wordlistsByLanguage = map[string][]string{
+	LanguageEnglish:            wordlists.English,
+	LanguageJapanese:           wordlists.Japanese,
+	LanguageSpanish:            wordlists.Spanish,
+	LanguageFrench:             wordlists.French,
+	LanguageItalian:            wordlists.Italian,
+	LanguageKorean:             wordlists.Korean,
+	LanguageChineseSimplified:  wordlists.ChineseSimplified,
+	LanguageChineseTraditional: wordlists.ChineseTraditional,
+	LanguageCzech:              wordlists.Czech,
+}
+
+// getWordlist resolve a language name to its wordlist, an empty name selects english
+func getWordlist(language string) ([]string, error) {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if language == "" {
+		language = LanguageEnglish
+	}
+
+	wordlist, ok := wordlistsByLanguage[language]
+	if !ok {
+		return nil, ErrUnsupportedLanguage
+	}
+
+	return wordlist, nil
+}
+
+// separator give the word separator of a language
+func separator(language string) string {
+	if strings.ToLower(strings.TrimSpace(language)) == LanguageJapanese {
+		return ideographicSpace
+	}
+	return " "
+}
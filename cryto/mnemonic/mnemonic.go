@@ -1,22 +1,76 @@
 package mnemonic
 
 import (
+	"crypto/sha256"
+	"errors"
+	"strings"
+
 	"github.com/tyler-smith/go-bip39"
+	"golang.org/x/text/unicode/norm"
 )
 
+// entropySize default entropy, 24 words
 const entropySize = 256
 
-// GetMnemonic give a random mnemonic words following BIP39 standard
-func GetMnemonic() (string, error)  {
-	entropy, err := bip39.NewEntropy(entropySize)
+// DefaultWordCount number of words of a default entropy mnemonic
+const DefaultWordCount = entropySize / 32 * 3
+
+var (
+	ErrInvalidMnemonic  = errors.New("invalid mnemonic")
+	ErrInvalidWordCount = errors.New("word count must be 12, 15, 18, 21 or 24")
+)
+
+// GetMnemonic give a random mnemonic words following BIP39 standard, of wordCount words in the language wordlist
+func GetMnemonic(wordCount int, language string) (string, error) {
+	if wordCount%3 != 0 || wordCount < 12 || wordCount > 24 {
+		return "", ErrInvalidWordCount
+	}
+
+	wordlist, err := getWordlist(language)
 	if err != nil {
 		return "", err
 	}
 
-	mnemonic, err := bip39.NewMnemonic(entropy)
+	// every 3 words carry 32 bits of entropy and 1 checksum bit
+	entropy, err := bip39.NewEntropy(wordCount / 3 * 32)
 	if err != nil {
 		return "", err
 	}
 
-	return mnemonic, nil
+	return strings.Join(entropyToWords(entropy, wordlist), separator(language)), nil
+}
+
+// entropyToWords split the entropy and its sha256 checksum in 11 bits wordlist indexes
+func entropyToWords(entropy []byte, wordlist []string) []string {
+	checksum := sha256.Sum256(entropy)
+	bits := append(append([]byte{}, entropy...), checksum[0])
+
+	wordCount := len(entropy) * 8 * 33 / 32 / 11
+	words := make([]string, wordCount)
+	for i := range words {
+		var index int
+		for b := i * 11; b < (i+1)*11; b++ {
+			index = index<<1 | int(bits[b/8]>>(7-uint(b%8))&1)
+		}
+		words[i] = wordlist[index]
+	}
+
+	return words
+}
+
+// ToSeed give the 64 bytes BIP39 seed of a checksum validated mnemonic and an optional passphrase,
+// the mnemonic language is detected from its words
+func ToSeed(mnemonic, passphrase string) ([]byte, error) {
+	validation, err := Validate(mnemonic, "")
+	if err != nil {
+		return nil, err
+	}
+	if !validation.Valid {
+		return nil, ErrInvalidMnemonic
+	}
+
+	// the seed is salted with the exact NFKD sentence, normalize user added whitespace
+	sentence := strings.Join(normalize(mnemonic), " ")
+
+	return bip39.NewSeed(sentence, norm.NFKD.String(passphrase)), nil
 }
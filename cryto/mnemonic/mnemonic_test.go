@@ -1,13 +1,16 @@
 package mnemonic
 
 import (
+	"encoding/hex"
 	"github.com/stretchr/testify/assert"
 	"github.com/tyler-smith/go-bip39"
+	"github.com/tyler-smith/go-bip39/wordlists"
+	"strings"
 	"testing"
 )
 
 func TestGetMnemonic_ReturnNormal(t *testing.T) {
-	var result, err = GetMnemonic()
+	var result, err = GetMnemonic(DefaultWordCount, LanguageEnglish)
 
 	assert.Empty(t, err)
 
@@ -19,3 +22,59 @@ func TestGetMnemonic_ReturnNormal(t *testing.T) {
 
 	assert.True(t, isEntrophSizeValid, "Invalid entropySize")
 }
+
+func TestToSeed(t *testing.T) {
+	// BIP39 test vector with the "TREZOR" passphrase
+	var seed, err = ToSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "TREZOR")
+
+	assert.NoError(t, err, "Expected no error: valid mnemonic")
+	assert.Equal(t, "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04", hex.EncodeToString(seed), "Incorrect seed")
+
+	seed, err = ToSeed("  abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon   about ", "")
+
+	assert.NoError(t, err, "Expected no error: valid mnemonic")
+	assert.Equal(t, "5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4", hex.EncodeToString(seed), "Incorrect seed")
+
+	_, err = ToSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", "")
+
+	assert.Equal(t, ErrInvalidMnemonic, err, "Expected error: invalid checksum")
+}
+
+func TestGetMnemonic_WordCountAndLanguage(t *testing.T) {
+	for _, wordCount := range []int{12, 15, 18, 21, 24} {
+		var result, err = GetMnemonic(wordCount, "")
+
+		assert.NoError(t, err, "Expected no error: valid word count")
+		assert.Len(t, strings.Fields(result), wordCount, "Incorrect word count")
+
+		_, err = bip39.EntropyFromMnemonic(result)
+
+		assert.NoError(t, err, "Expected no error: valid mnemonic")
+	}
+
+	var result, err = GetMnemonic(12, LanguageJapanese)
+
+	assert.NoError(t, err, "Expected no error: valid language")
+	assert.Len(t, strings.Split(result, "\u3000"), 12, "Expected ideographic space separated words")
+
+	_, err = GetMnemonic(13, LanguageEnglish)
+
+	assert.Equal(t, ErrInvalidWordCount, err, "Expected error: invalid word count")
+
+	_, err = GetMnemonic(12, "klingon")
+
+	assert.Equal(t, ErrUnsupportedLanguage, err, "Expected error: unsupported language")
+}
+
+func TestEntropyToWords(t *testing.T) {
+	// BIP39 test vector
+	var entropy, _ = hex.DecodeString("7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f")
+	var words = entropyToWords(entropy, wordlists.English)
+
+	assert.Equal(t, "legal winner thank year wave sausage worth useful legal winner thank yellow", strings.Join(words, " "), "Incorrect mnemonic")
+
+	entropy, _ = hex.DecodeString("ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	words = entropyToWords(entropy, wordlists.English)
+
+	assert.Equal(t, "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote", strings.Join(words, " "), "Incorrect mnemonic")
+}
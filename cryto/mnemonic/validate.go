@@ -0,0 +1,249 @@
+package mnemonic
+
+import (
+	"crypto/sha256"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// maxSuggestionDistance largest edit distance of a suggested word
+const maxSuggestionDistance = 2
+
+// maxSuggestions number of suggested words per unknown word
+const maxSuggestions = 3
+
+// languagePreference detection order, the first best match wins
+var languagePreference = []string{
+	LanguageEnglish,
+	LanguageSpanish,
+	LanguageFrench,
+	LanguageItalian,
+	LanguageCzech,
+	LanguageJapanese,
+	LanguageKorean,
+	LanguageChineseSimplified,
+	LanguageChineseTraditional,
+}
+
+// UnknownWord a mnemonic word missing from the wordlist, with its closest wordlist words
+type UnknownWord struct {
+	Index       int
+	Word        string
+	Suggestions []string
+}
+
+// Validation report of a mnemonic check
+type Validation struct {
+	Valid          bool
+	Language       string
+	WordCount      int
+	ValidWordCount bool
+	UnknownWords   []UnknownWord
+	ChecksumValid  bool
+}
+
+// normalize apply the BIP39 NFKD normalization and split the sentence in lower case words
+func normalize(mnemonic string) []string {
+	return strings.Fields(strings.ToLower(norm.NFKD.String(mnemonic)))
+}
+
+// detectLanguage pick the language whose wordlist knows the most words
+func detectLanguage(words []string) string {
+	var best = LanguageEnglish
+	var bestCount = -1
+	for _, language := range languagePreference {
+		index := wordIndex(wordlistsByLanguage[language])
+
+		count := 0
+		for _, word := range words {
+			if _, ok := index[word]; ok {
+				count++
+			}
+		}
+		if count > bestCount {
+			best, bestCount = language, count
+		}
+	}
+
+	return best
+}
+
+// wordIndex reverse lookup of a wordlist
+func wordIndex(wordlist []string) map[string]int {
+	index := make(map[string]int, len(wordlist))
+	for i, word := range wordlist {
+		index[word] = i
+	}
+	return index
+}
+
+// resolveLanguage validate the requested language, or detect it from the words when empty
+func resolveLanguage(words []string, language string) (string, []string, error) {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if language == "" {
+		language = detectLanguage(words)
+	}
+
+	wordlist, err := getWordlist(language)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return language, wordlist, nil
+}
+
+// checksumValid check the trailing checksum bits of 11 bits wordlist indexes
+func checksumValid(indexes []int) bool {
+	bits := len(indexes) * 11
+	checksumBits := bits / 33
+	entropyBytes := (bits - checksumBits) / 8
+
+	data := make([]byte, (bits+7)/8)
+	for i, index := range indexes {
+		for b := 0; b < 11; b++ {
+			if index>>(10-uint(b))&1 == 1 {
+				pos := i*11 + b
+				data[pos/8] |= 1 << (7 - uint(pos%8))
+			}
+		}
+	}
+
+	hash := sha256.Sum256(data[:entropyBytes])
+	expected := hash[0] >> (8 - uint(checksumBits))
+	actual := data[entropyBytes] >> (8 - uint(checksumBits))
+
+	return expected == actual
+}
+
+// Validate check a mnemonic against a wordlist and its checksum, an empty language is detected from the words
+func Validate(mnemonic, language string) (*Validation, error) {
+	words := normalize(mnemonic)
+
+	language, wordlist, err := resolveLanguage(words, language)
+	if err != nil {
+		return nil, err
+	}
+	index := wordIndex(wordlist)
+
+	result := &Validation{
+		Language:       language,
+		WordCount:      len(words),
+		ValidWordCount: len(words)%3 == 0 && len(words) >= 12 && len(words) <= 24,
+		UnknownWords:   []UnknownWord{},
+	}
+
+	indexes := make([]int, 0, len(words))
+	for i, word := range words {
+		position, ok := index[word]
+		if !ok {
+			result.UnknownWords = append(result.UnknownWords, UnknownWord{
+				Index:       i,
+				Word:        word,
+				Suggestions: suggest(word, wordlist),
+			})
+			continue
+		}
+		indexes = append(indexes, position)
+	}
+
+	if result.ValidWordCount && len(result.UnknownWords) == 0 {
+		result.ChecksumValid = checksumValid(indexes)
+	}
+	result.Valid = result.ValidWordCount && len(result.UnknownWords) == 0 && result.ChecksumValid
+
+	return result, nil
+}
+
+// CompleteLastWord give every wordlist word completing 11, 14, 17, 20 or 23 words to a valid checksum mnemonic
+func CompleteLastWord(mnemonic, language string) ([]string, error) {
+	words := normalize(mnemonic)
+	if (len(words)+1)%3 != 0 || len(words)+1 < 12 || len(words)+1 > 24 {
+		return nil, ErrInvalidWordCount
+	}
+
+	_, wordlist, err := resolveLanguage(words, language)
+	if err != nil {
+		return nil, err
+	}
+	index := wordIndex(wordlist)
+
+	indexes := make([]int, len(words)+1)
+	for i, word := range words {
+		position, ok := index[word]
+		if !ok {
+			return nil, ErrInvalidMnemonic
+		}
+		indexes[i] = position
+	}
+
+	var result []string
+	for candidate := range wordlist {
+		indexes[len(words)] = candidate
+		if checksumValid(indexes) {
+			result = append(result, wordlist[candidate])
+		}
+	}
+
+	return result, nil
+}
+
+// suggest give the closest wordlist words by edit distance
+func suggest(word string, wordlist []string) []string {
+	type candidate struct {
+		word     string
+		distance int
+	}
+
+	var candidates []candidate
+	for _, w := range wordlist {
+		if d := editDistance(word, w); d <= maxSuggestionDistance {
+			candidates = append(candidates, candidate{word: w, distance: d})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+
+	result := []string{}
+	for i := 0; i < len(candidates) && i < maxSuggestions; i++ {
+		result = append(result, candidates[i].word)
+	}
+
+	return result
+}
+
+// editDistance Levenshtein distance between two words, counted in runes
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min3(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
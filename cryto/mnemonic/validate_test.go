@@ -0,0 +1,71 @@
+package mnemonic
+
+import (
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	var result, err = Validate("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "")
+
+	assert.NoError(t, err, "Expected no error: supported language")
+	assert.True(t, result.Valid, "Expected a valid mnemonic")
+	assert.Equal(t, LanguageEnglish, result.Language, "Expected english to be detected")
+
+	result, err = Validate("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", "")
+
+	assert.NoError(t, err, "Expected no error: supported language")
+	assert.False(t, result.Valid, "Expected an invalid mnemonic")
+	assert.False(t, result.ChecksumValid, "Expected a checksum failure")
+	assert.Empty(t, result.UnknownWords, "Expected every word to be known")
+
+	result, err = Validate("abandon abandon abandon abandon abandn abandon abandon abandon abandon abandon abandon about", "")
+
+	assert.NoError(t, err, "Expected no error: supported language")
+	assert.False(t, result.Valid, "Expected an invalid mnemonic")
+	assert.Len(t, result.UnknownWords, 1, "Expected one unknown word")
+	assert.Equal(t, 4, result.UnknownWords[0].Index, "Incorrect unknown word index")
+	assert.Equal(t, "abandon", result.UnknownWords[0].Suggestions[0], "Expected the closest word first")
+
+	result, err = Validate("abandon abandon about", "")
+
+	assert.NoError(t, err, "Expected no error: supported language")
+	assert.False(t, result.ValidWordCount, "Expected an invalid word count")
+
+	// NFC input matches the NFKD spanish wordlist
+	result, err = Validate("ábaco ábaco ábaco ábaco ábaco ábaco ábaco ábaco ábaco ábaco ábaco abierto", LanguageSpanish)
+
+	assert.NoError(t, err, "Expected no error: supported language")
+	assert.Empty(t, result.UnknownWords, "Expected every word to be known")
+
+	_, err = Validate("abandon", "klingon")
+
+	assert.Equal(t, ErrUnsupportedLanguage, err, "Expected error: unsupported language")
+}
+
+func TestCompleteLastWord(t *testing.T) {
+	var words, err = CompleteLastWord("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", "")
+
+	assert.NoError(t, err, "Expected no error: 11 known words")
+	assert.Len(t, words, 128, "Expected 2^7 valid last words for 12 words")
+	assert.Contains(t, words, "about", "Expected the test vector last word")
+
+	words, err = CompleteLastWord("zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo", "")
+
+	assert.NoError(t, err, "Expected no error: 23 known words")
+	assert.Len(t, words, 8, "Expected 2^3 valid last words for 24 words")
+	assert.Contains(t, words, "vote", "Expected the test vector last word")
+
+	_, err = CompleteLastWord("abandon abandon", "")
+
+	assert.Equal(t, ErrInvalidWordCount, err, "Expected error: invalid word count")
+}
+
+func TestToSeed_Japanese(t *testing.T) {
+	// BIP39 japanese test vector, ideographic spaces and NFKD normalized passphrase
+	var seed, err = ToSeed("あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あおぞら", "㍍ガバヴァぱばぐゞちぢ十人十色")
+
+	assert.NoError(t, err, "Expected no error: valid japanese mnemonic")
+	assert.Equal(t, "a262d6fb6122ecf45be09c50492b31f92e9beb7d9a845987a02cefda57a15f9c467a17872029a9e92299b5cbdf306e3a0ee620245cbd508959b6cb7ca637bd55", hex.EncodeToString(seed), "Incorrect seed")
+}
@@ -0,0 +1,117 @@
+package multisig
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// OP_DATA_33 and OP_DATA_65 pushes of a compressed and an uncompressed public key
+const (
+	OP_DATA_33 = 33
+	OP_DATA_65 = 65
+)
+
+var (
+	ErrInvalidScriptHex     = errors.New("script is not hex encoded")
+	ErrNotMultisigScript    = errors.New("script is not a standard <OP_m> <pubkeys...> <OP_n> OP_CHECKMULTISIG script")
+	ErrWitnessProgramScript = errors.New("script is a P2SH-P2WSH witness program, decode its witness script instead")
+)
+
+// DecodedScript a multisig script decoded from its serialization
+type DecodedScript struct {
+	M          int
+	N          int
+	PublicKeys [][]byte
+	Multisig   []byte
+}
+
+// DecodeScript decode a m-of-n multisig redeem or witness script in hex, as GenerateAddress emits it
+func DecodeScript(scriptHex string) (*DecodedScript, error) {
+	script, err := hex.DecodeString(strings.TrimSpace(scriptHex))
+	if err != nil || len(script) == 0 {
+		return nil, ErrInvalidScriptHex
+	}
+
+	return ParseScript(script)
+}
+
+// ParseScript decode a serialized m-of-n multisig redeem or witness script
+func ParseScript(script []byte) (*DecodedScript, error) {
+	if len(script) == 34 && script[0] == OP_0 && script[1] == OP_DATA_32 {
+		return nil, ErrWitnessProgramScript
+	}
+	if len(script) > maxRedeemScriptSize {
+		return nil, ErrScriptSize
+	}
+
+	// <OP_m> ... <OP_n> OP_CHECKMULTISIG
+	if len(script) < 3 || script[len(script)-1] != OP_CHECKMULTISIG {
+		return nil, ErrNotMultisigScript
+	}
+	m, ok := smallInt(script[0])
+	if !ok {
+		return nil, ErrNotMultisigScript
+	}
+	n, ok := smallInt(script[len(script)-2])
+	if !ok {
+		return nil, ErrNotMultisigScript
+	}
+
+	// <pubkeys...> pushed with OP_DATA_33 or OP_DATA_65
+	var publicKeys [][]byte
+	var body = script[1 : len(script)-2]
+	for len(body) > 0 {
+		size := int(body[0])
+		if (size != OP_DATA_33 && size != OP_DATA_65) || len(body) < 1+size {
+			return nil, ErrNotMultisigScript
+		}
+		publicKeys = append(publicKeys, body[1:1+size])
+		body = body[1+size:]
+	}
+	if len(publicKeys) != n {
+		return nil, ErrNotMultisigScript
+	}
+	if n > maxPublicKeys {
+		return nil, ErrNRange
+	}
+	if m > n {
+		return nil, ErrMRange
+	}
+	for _, publicKey := range publicKeys {
+		if err := isPublicKeyValid(publicKey); err != nil {
+			return nil, err
+		}
+	}
+
+	return &DecodedScript{M: m, N: n, PublicKeys: publicKeys, Multisig: script}, nil
+}
+
+// smallInt give the value pushed by OP_1 through OP_16
+func smallInt(opcode byte) (int, bool) {
+	if opcode < OP_1 || opcode > OP_16 {
+		return 0, false
+	}
+	return int(opcode) - OP_1 + 1, true
+}
+
+// Compressed tell whether every public key is compressed, as segwit script types require
+func (d *DecodedScript) Compressed() bool {
+	for _, publicKey := range d.PublicKeys {
+		if len(publicKey) != 33 {
+			return false
+		}
+	}
+	return true
+}
+
+// Address encode the address paying to the script for the script type on a network
+func (d *DecodedScript) Address(scriptType ScriptType, network *chaincfg.Params) (string, error) {
+	if scriptType.isWitness() && !d.Compressed() {
+		return "", ErrUncompressedWitnessKey
+	}
+
+	return scriptAddress(d.Multisig, scriptType, network)
+}
@@ -0,0 +1,61 @@
+package multisig
+
+import (
+	"encoding/hex"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDecodeScript(t *testing.T) {
+	var _, scriptHex, _ = GenerateAddress(2, 3, []string{uncompressedKey1, compressedKey2, compressedKey3})
+	var decoded, err = DecodeScript(scriptHex)
+
+	assert.NoError(t, err, "Expected no error: valid multisig script")
+	assert.Equal(t, 2, decoded.M, "Incorrect m")
+	assert.Equal(t, 3, decoded.N, "Incorrect n")
+	assert.Equal(t, uncompressedKey1, hex.EncodeToString(decoded.PublicKeys[0]), "Incorrect public key")
+	assert.Equal(t, compressedKey3, hex.EncodeToString(decoded.PublicKeys[2]), "Incorrect public key")
+	assert.False(t, decoded.Compressed(), "Expected an uncompressed key")
+
+	address, err := decoded.Address(ScriptTypeP2SH, &chaincfg.MainNetParams)
+
+	assert.NoError(t, err, "Expected no error: valid address")
+	assert.Equal(t, "36W6qrKySYEQnasdASqirnR3BA51PJhLUg", address, "Incorrect P2SH address")
+
+	_, err = decoded.Address(ScriptTypeP2WSH, &chaincfg.MainNetParams)
+
+	assert.Equal(t, ErrUncompressedWitnessKey, err, "Expected error: uncompressed key in witness script")
+
+	decoded, _ = DecodeScript("5221" + compressedKey1 + "21" + compressedKey2 + "21" + compressedKey3 + "53ae")
+	address, err = decoded.Address(ScriptTypeP2WSH, &chaincfg.MainNetParams)
+
+	assert.NoError(t, err, "Expected no error: valid address")
+	assert.Equal(t, "bc1qznadz5sy773nv3pr2n2jmymcyzkrn64tttz5640nz6988zmemzfs3dn6jt", address, "Incorrect P2WSH address")
+}
+
+func TestDecodeScript_Errors(t *testing.T) {
+	var _, err = DecodeScript("zz")
+
+	assert.Equal(t, ErrInvalidScriptHex, err, "Expected error: not hex")
+
+	_, err = DecodeScript("0020" + "1111111111111111111111111111111111111111111111111111111111111111")
+
+	assert.Equal(t, ErrWitnessProgramScript, err, "Expected error: witness program")
+
+	_, err = DecodeScript("76a914" + "1111111111111111111111111111111111111111" + "88ac")
+
+	assert.Equal(t, ErrNotMultisigScript, err, "Expected error: P2PKH script")
+
+	_, err = DecodeScript("5221" + compressedKey1 + "21" + compressedKey2 + "53ae")
+
+	assert.Equal(t, ErrNotMultisigScript, err, "Expected error: n does not match the key count")
+
+	_, err = DecodeScript("5321" + compressedKey1 + "21" + compressedKey2 + "52ae")
+
+	assert.Equal(t, ErrMRange, err, "Expected error: m > n")
+
+	_, err = DecodeScript("5121" + "02" + "0000000000000000000000000000000000000000000000000000000000000000" + "51ae")
+
+	assert.Equal(t, ErrInvalidPubKey, err, "Expected error: key not on the curve")
+}
@@ -0,0 +1,223 @@
+package multisig
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"btcwalletapi/cryto/segwit"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/tyler-smith/go-bip32"
+)
+
+const (
+	// PurposeBIP45 m/45'/cosigner_index/change/index
+	PurposeBIP45 = 0x8000002D
+	// PurposeBIP48 m/48'/coin_type'/account'/script_type'/change/index
+	PurposeBIP48 = 0x80000030
+	// bip48ScriptTypeP2SHP2WSH BIP48 script_type' of nested segwit multisig
+	bip48ScriptTypeP2SHP2WSH = 0x80000001
+	// bip48ScriptTypeP2WSH BIP48 script_type' of native segwit multisig
+	bip48ScriptTypeP2WSH = 0x80000002
+	// fingerprintLength bytes of a key origin fingerprint
+	fingerprintLength = 4
+)
+
+var (
+	ErrEmptyCosigners        = errors.New("cosigner extended public keys cannot be empty")
+	ErrInvalidFingerprint    = fmt.Errorf("key origin fingerprint must be %d bytes in hex", fingerprintLength)
+	ErrOriginDepthMismatch   = errors.New("key origin path depth does not match the extended key depth")
+	ErrScriptTypeMismatch    = errors.New("script type does not match the BIP45/BIP48 key origin path")
+	ErrCosignerNetworkMixed  = errors.New("cosigner extended public keys belong to different networks")
+	ErrMissingCosignerIndex  = errors.New("BIP45 cosigners need a cosigner index")
+	ErrHardenedCosignerIndex = errors.New("cosigner index cannot be hardened")
+)
+
+// CosignerKey a cosigner extended public key with its optional key origin, as given by the cosigner
+type CosignerKey struct {
+	XPub string
+	// Fingerprint master key fingerprint in hex
+	Fingerprint string
+	// Path derivation path from the master key to the extended public key
+	Path string
+}
+
+// Cosigner a decoded cosigner extended public key
+type Cosigner struct {
+	Key *bip32.Key
+	// Fingerprint master key fingerprint, empty when unknown
+	Fingerprint []byte
+	// Origin derivation path from the master key to Key, empty when unknown
+	Origin []uint32
+}
+
+// HDMultisig a m-of-n multisig wallet of cosigner extended public keys, child keys are sorted per BIP67
+type HDMultisig struct {
+	M          int
+	Cosigners  []*Cosigner
+	ScriptType ScriptType
+	Network    *chaincfg.Params
+	// CosignerIndex BIP45 cosigner_index level derived before change/index, nil for BIP48 and plain xpubs
+	CosignerIndex *uint32
+}
+
+// HDAddress a multisig address derived at a relative path of every cosigner key
+type HDAddress struct {
+	Path   string
+	Script *Script
+}
+
+// parseCosigner decode a cosigner extended public key and its key origin
+func parseCosigner(cosignerKey CosignerKey, network *chaincfg.Params) (*Cosigner, *chaincfg.Params, error) {
+	key, network, err := segwit.ParseCosignerExtendedPublicKey(strings.TrimSpace(cosignerKey.XPub), network)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var cosigner = &Cosigner{Key: key}
+	if cosignerKey.Fingerprint != "" {
+		cosigner.Fingerprint, err = hex.DecodeString(cosignerKey.Fingerprint)
+		if err != nil || len(cosigner.Fingerprint) != fingerprintLength {
+			return nil, nil, ErrInvalidFingerprint
+		}
+	}
+	if cosignerKey.Path != "" {
+		origin, relative, err := segwit.ParsePath(cosignerKey.Path)
+		if err != nil {
+			return nil, nil, err
+		}
+		if relative {
+			return nil, nil, segwit.ErrInvalidPath
+		}
+		if len(origin) != int(key.Depth) {
+			return nil, nil, ErrOriginDepthMismatch
+		}
+		cosigner.Origin = origin
+	}
+
+	return cosigner, network, nil
+}
+
+// originScriptType give the script type a BIP45/BIP48 key origin stands for, empty for other origins
+func originScriptType(origin []uint32) ScriptType {
+	switch {
+	case len(origin) == 1 && origin[0] == PurposeBIP45:
+		return ScriptTypeP2SH
+	case len(origin) == 4 && origin[0] == PurposeBIP48 && origin[3] == bip48ScriptTypeP2SHP2WSH:
+		return ScriptTypeP2SHP2WSH
+	case len(origin) == 4 && origin[0] == PurposeBIP48 && origin[3] == bip48ScriptTypeP2WSH:
+		return ScriptTypeP2WSH
+	default:
+		return ""
+	}
+}
+
+// NewHDMultisig decode the cosigner keys of a m-of-n multisig wallet, an empty script type follows the BIP45/BIP48
+// key origins (P2WSH otherwise) and a nil network follows the key versions
+func NewHDMultisig(m int, cosignerKeys []CosignerKey, scriptType ScriptType, network *chaincfg.Params, cosignerIndex *uint32) (*HDMultisig, error) {
+	if len(cosignerKeys) == 0 {
+		return nil, ErrEmptyCosigners
+	}
+	if len(cosignerKeys) > maxPublicKeys {
+		return nil, ErrNRange
+	}
+	if m < 1 || m > len(cosignerKeys) {
+		return nil, ErrMRange
+	}
+	if cosignerIndex != nil && *cosignerIndex >= segwit.Apostrophe {
+		return nil, ErrHardenedCosignerIndex
+	}
+
+	var cosigners = make([]*Cosigner, len(cosignerKeys))
+	var keyNetwork *chaincfg.Params
+	for i, cosignerKey := range cosignerKeys {
+		cosigner, cosignerNetwork, err := parseCosigner(cosignerKey, network)
+		if err != nil {
+			return nil, err
+		}
+		if keyNetwork != nil && keyNetwork.Net != cosignerNetwork.Net {
+			return nil, ErrCosignerNetworkMixed
+		}
+		keyNetwork = cosignerNetwork
+
+		// BIP45/BIP48 origins commit to a script type
+		if originType := originScriptType(cosigner.Origin); originType != "" {
+			if scriptType == "" {
+				scriptType = originType
+			}
+			if scriptType != originType {
+				return nil, ErrScriptTypeMismatch
+			}
+			if originType == ScriptTypeP2SH && cosignerIndex == nil {
+				return nil, ErrMissingCosignerIndex
+			}
+		}
+		cosigners[i] = cosigner
+	}
+	if scriptType == "" {
+		scriptType = ScriptTypeP2WSH
+	}
+
+	return &HDMultisig{
+		M:             m,
+		Cosigners:     cosigners,
+		ScriptType:    scriptType,
+		Network:       keyNetwork,
+		CosignerIndex: cosignerIndex,
+	}, nil
+}
+
+// RelativePath give the path derived from every cosigner key, [cosigner_index/]change/index
+func (hd *HDMultisig) RelativePath(change, index uint32) []uint32 {
+	if hd.CosignerIndex != nil {
+		return []uint32{*hd.CosignerIndex, change, index}
+	}
+	return []uint32{change, index}
+}
+
+// Address derive the multisig address of every cosigner child key at change/index
+func (hd *HDMultisig) Address(change, index uint32) (*HDAddress, error) {
+	if change >= segwit.Apostrophe || index >= segwit.Apostrophe {
+		return nil, segwit.ErrHardenedPublicDerivation
+	}
+
+	var path = hd.RelativePath(change, index)
+	var publicKeys = make([][]byte, len(hd.Cosigners))
+	for i, cosigner := range hd.Cosigners {
+		key := cosigner.Key
+		for _, component := range path {
+			child, err := key.NewChildKey(component)
+			if err != nil {
+				return nil, err
+			}
+			key = child
+		}
+		publicKeys[i] = key.Key
+	}
+
+	script, err := newScript(hd.M, len(publicKeys), publicKeys, hd.ScriptType, true, hd.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HDAddress{Path: segwit.FormatPath(path, true), Script: script}, nil
+}
+
+// AddressRange derive count consecutive multisig addresses from start on a chain
+func (hd *HDMultisig) AddressRange(change, start, count uint32) ([]*HDAddress, error) {
+	if uint64(start)+uint64(count) > uint64(segwit.Apostrophe) {
+		return nil, segwit.ErrIndexOutOfRange
+	}
+
+	var addresses = make([]*HDAddress, 0, count)
+	for index := start; index < start+count; index++ {
+		address, err := hd.Address(change, index)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, address)
+	}
+
+	return addresses, nil
+}
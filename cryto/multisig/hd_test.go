@@ -0,0 +1,112 @@
+package multisig
+
+import (
+	"btcwalletapi/cryto/segwit"
+	"encoding/hex"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// cosigner keys m/48'/0'/account'/2' of the "abandon ... about" seed, accounts 0' to 2'
+var bip48Cosigners = []CosignerKey{
+	{XPub: "xpub6DkFAXWQ2dHxq2vatrt9qyA3bXYU4ToWQwCHbf5XB2mSTexcHZCeKS1VZYcPoBd5X8yVcbXFHJR9R8UCVpt82VX1VhR28mCyxUFL4r6KFrf", Fingerprint: "73c5da0a", Path: "m/48'/0'/0'/2'"},
+	{XPub: "xpub6DzhyrnFFYQ1HimDiM388xHnDiRPNdZJFBmmxge3Y1WWcHLtMJLfRuhRHqnQCPbTj3fGKTuKFLHzzwpJkp5Dtc3UtLKZKaVZe1yqMBXd6Vk", Fingerprint: "73c5da0a", Path: "m/48'/0'/1'/2'"},
+	{XPub: "xpub6EGx8sPr9FxPPE1rbZazhqWwpMXA3Hf5DYKtZbL7c4BSddzmQktp96UaTvecEkoCZysuaj79GMCFZYT1KKk7Ph2M3Kf5g8B82KZ8TZ9SKQR", Fingerprint: "73c5da0a", Path: "m/48'/0'/2'/2'"},
+}
+
+func TestNewHDMultisig(t *testing.T) {
+	var hd, err = NewHDMultisig(2, bip48Cosigners, "", nil, nil)
+
+	assert.NoError(t, err, "Expected no error: valid cosigners")
+	assert.Equal(t, ScriptTypeP2WSH, hd.ScriptType, "Expected P2WSH from the BIP48 script type")
+	assert.Equal(t, chaincfg.MainNetParams.Net, hd.Network.Net, "Expected mainnet from the key versions")
+
+	_, err = NewHDMultisig(2, bip48Cosigners, ScriptTypeP2SH, nil, nil)
+
+	assert.Equal(t, ErrScriptTypeMismatch, err, "Expected error: script type against BIP48 origin")
+
+	_, err = NewHDMultisig(2, bip48Cosigners, "", &chaincfg.TestNet3Params, nil)
+
+	assert.Error(t, err, "Expected error: mainnet keys on testnet")
+
+	_, err = NewHDMultisig(4, bip48Cosigners, "", nil, nil)
+
+	assert.Equal(t, ErrMRange, err, "Expected error: m > n")
+
+	var badOrigin = []CosignerKey{bip48Cosigners[0], bip48Cosigners[1], {XPub: bip48Cosigners[2].XPub, Path: "m/48'/0'/2'"}}
+	_, err = NewHDMultisig(2, badOrigin, "", nil, nil)
+
+	assert.Equal(t, ErrOriginDepthMismatch, err, "Expected error: origin path shorter than the key depth")
+
+	var badFingerprint = []CosignerKey{bip48Cosigners[0], bip48Cosigners[1], {XPub: bip48Cosigners[2].XPub, Fingerprint: "73c5"}}
+	_, err = NewHDMultisig(2, badFingerprint, "", nil, nil)
+
+	assert.Equal(t, ErrInvalidFingerprint, err, "Expected error: short fingerprint")
+}
+
+func TestHDMultisig_AddressRange(t *testing.T) {
+	var hd, _ = NewHDMultisig(2, bip48Cosigners, "", nil, nil)
+	var addresses, err = hd.AddressRange(0, 0, 2)
+
+	assert.NoError(t, err, "Expected no error: valid range")
+	assert.Len(t, addresses, 2, "Incorrect range length")
+	assert.Equal(t, "0/1", addresses[1].Path, "Incorrect path")
+	assert.Equal(t, "bc1qg8fpeqrl9uf3w5vawye5s235xylqhyxd7gjs4hq78crn6sm5w3asar4472", addresses[1].Script.Address, "Incorrect P2WSH address")
+	assert.True(t, addresses[1].Script.Sorted, "Expected BIP67 sorted keys")
+
+	// the address does not depend on the cosigner order
+	var reversed = []CosignerKey{bip48Cosigners[2], bip48Cosigners[1], bip48Cosigners[0]}
+	hd, _ = NewHDMultisig(2, reversed, "", nil, nil)
+	address, _ := hd.Address(0, 1)
+
+	assert.Equal(t, addresses[1].Script.Address, address.Script.Address, "Expected the same address whatever the cosigner order")
+
+	var plain = []CosignerKey{{XPub: bip48Cosigners[0].XPub}, {XPub: bip48Cosigners[1].XPub}, {XPub: bip48Cosigners[2].XPub}}
+	hd, _ = NewHDMultisig(2, plain, ScriptTypeP2SHP2WSH, nil, nil)
+	address, err = hd.Address(0, 1)
+
+	assert.NoError(t, err, "Expected no error: valid address")
+	assert.Equal(t, addresses[1].Script.Multisig, address.Script.WitnessScript(), "Expected the same witness script nested in P2SH")
+	assert.Equal(t, "3BkwNX3nmb6nE5toEDAG4BDeSxVr2y3EDE", address.Script.Address, "Incorrect P2SH-P2WSH address")
+
+	_, err = hd.Address(0, 0x80000000)
+
+	assert.Error(t, err, "Expected error: hardened index")
+}
+
+func TestHDMultisig_BIP45(t *testing.T) {
+	var cosigners = []CosignerKey{
+		{XPub: bip48Cosigners[0].XPub, Fingerprint: "73c5da0a", Path: "m/45'/0'/0'/2'"},
+	}
+	var hd, err = NewHDMultisig(1, cosigners, "", nil, nil)
+
+	assert.NoError(t, err, "Expected no error: a deeper 45' origin is not BIP45")
+	assert.Equal(t, ScriptTypeP2WSH, hd.ScriptType, "Expected the default script type")
+
+	var index uint32 = 1
+	hd, err = NewHDMultisig(1, []CosignerKey{{XPub: bip48Cosigners[0].XPub}}, ScriptTypeP2SH, nil, &index)
+
+	assert.NoError(t, err, "Expected no error: valid cosigner index")
+
+	address, err := hd.Address(0, 3)
+
+	assert.NoError(t, err, "Expected no error: valid address")
+	assert.Equal(t, "1/0/3", address.Path, "Expected the cosigner index before change/index")
+}
+
+func TestHDMultisig_BIP45CosignerIndex(t *testing.T) {
+	var seed, _ = hex.DecodeString("5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4")
+	var key, _ = segwit.DeriveFromSeed(seed, &chaincfg.MainNetParams, []uint32{PurposeBIP45})
+	var cosigners = []CosignerKey{{XPub: key.ExtendedPublicKey, Fingerprint: "73c5da0a", Path: "m/45'"}}
+
+	var _, err = NewHDMultisig(1, cosigners, "", nil, nil)
+
+	assert.Equal(t, ErrMissingCosignerIndex, err, "Expected error: BIP45 without cosigner index")
+
+	var index uint32 = 0
+	hd, err := NewHDMultisig(1, cosigners, "", nil, &index)
+
+	assert.NoError(t, err, "Expected no error: BIP45 with cosigner index")
+	assert.Equal(t, ScriptTypeP2SH, hd.ScriptType, "Expected P2SH from the BIP45 origin")
+}
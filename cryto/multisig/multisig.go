@@ -6,9 +6,11 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
-	"github.com/btcsuite/btcutil/base58"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
 	"golang.org/x/crypto/ripemd160"
 )
 
@@ -37,9 +39,16 @@ const (
 	OP_CHECKMULTISIG = 174
 )
 
+const (
+	// maxPublicKeys largest standard multisig quorum
+	maxPublicKeys = 15
+	// maxRedeemScriptSize largest script element pushed by the spending input
+	maxRedeemScriptSize = 520
+)
+
 var (
 	ErrOffendPubKey = errors.New("offending publicKey")
-	ErrNRange       = errors.New("N must be between 1 and 7 (inclusive) for valid, standard P2SH multisig transaction as per Bitcoin protocol")
+	ErrNRange       = errors.New("N must be between 1 and 15 (inclusive) for valid, standard P2SH multisig transaction as per Bitcoin protocol")
 	ErrMRange       = errors.New("M must be between 1 and N (inclusive)")
 	ErrNumOfPubKeys = func(n int, m int, numOfPubKeys int) error {
 		return fmt.Errorf("need exactly %d public keys to create P2SH address for %d-of-%d multisig transaction. Only %d keys provided", n, m, n, numOfPubKeys)
@@ -47,9 +56,39 @@ var (
 	ErrEmptyBytes    = errors.New("empty bytes")
 	ErrEmptyPubKey   = errors.New("public key cannot be empty")
 	ErrInvalidPubKey = errors.New("public key invalid")
+	ErrScriptSize    = fmt.Errorf("redeem script larger than %d bytes, use compressed public keys", maxRedeemScriptSize)
+	// ErrUncompressedSortedKey BIP67 only defines the sort order of compressed public keys
+	ErrUncompressedSortedKey = errors.New("BIP67 key sorting only accepts compressed public keys")
 )
 
+// Script a m-of-n multisig script and the address committing to it
+type Script struct {
+	Type ScriptType
+	M    int
+	N    int
+	// PublicKeys keys in script order
+	PublicKeys [][]byte
+	// Sorted keys sorted per BIP67
+	Sorted bool
+	// Multisig <OP_m> <pubkeys...> <OP_n> OP_CHECKMULTISIG, the redeem script of P2SH
+	// or the witness script of P2WSH and P2SH-P2WSH
+	Multisig []byte
+	Address  string
+}
+
+// GenerateAddress give the legacy P2SH address of a m-of-n multisig and its redeem script in hex
 func GenerateAddress(flagM int, flagN int, publicKeyStrings []string) (string, string, error) {
+	script, err := GenerateScriptAddress(flagM, flagN, publicKeyStrings, ScriptTypeP2SH, false, &chaincfg.MainNetParams)
+	if err != nil {
+		return "", "", err
+	}
+
+	return script.Address, hex.EncodeToString(script.Multisig), nil
+}
+
+// GenerateScriptAddress give the m-of-n multisig script and its address for the script type on a network,
+// public keys are sorted per BIP67 when sortKeys is set, which requires compressed keys
+func GenerateScriptAddress(flagM int, flagN int, publicKeyStrings []string, scriptType ScriptType, sortKeys bool, network *chaincfg.Params) (*Script, error) {
 	var err error
 
 	publicKeys := make([][]byte, len(publicKeyStrings))
@@ -57,29 +96,62 @@ func GenerateAddress(flagM int, flagN int, publicKeyStrings []string) (string, s
 		publicKeyString = strings.TrimSpace(publicKeyString)
 		publicKeys[i], err = hex.DecodeString(publicKeyString)
 		if err != nil {
-			return "", "", ErrOffendPubKey
+			return nil, ErrOffendPubKey
+		}
+	}
+
+	return newScript(flagM, flagN, publicKeys, scriptType, sortKeys, network)
+}
+
+// newScript build the m-of-n multisig script of serialized public keys and its address
+func newScript(m int, n int, publicKeys [][]byte, scriptType ScriptType, sortKeys bool, network *chaincfg.Params) (*Script, error) {
+	if scriptType.isWitness() {
+		for _, publicKey := range publicKeys {
+			if len(publicKey) != 33 {
+				return nil, ErrUncompressedWitnessKey
+			}
+		}
+	}
+	if sortKeys {
+		for _, publicKey := range publicKeys {
+			if len(publicKey) != 33 {
+				return nil, ErrUncompressedSortedKey
+			}
 		}
+		sortPublicKeys(publicKeys)
 	}
 	// create redeemScript from public keys
-	redeemScript, err := newMOfNRedeemScript(flagM, flagN, publicKeys)
+	redeemScript, err := newMOfNRedeemScript(m, n, publicKeys)
 	if err != nil {
-		return "", "", err
+		return nil, err
 	}
-	redeemScriptHash, err := hash160(redeemScript)
+	// get address committing to the script
+	address, err := scriptAddress(redeemScript, scriptType, network)
 	if err != nil {
-		return "", "", err
+		return nil, err
 	}
-	// get P2SH address by base58 encoding with P2SH prefix 0x05
-	P2SHAddress := base58.CheckEncode(redeemScriptHash, 5)
-	// get redeemScript in Hex
-	redeemScriptHex := hex.EncodeToString(redeemScript)
 
-	return P2SHAddress, redeemScriptHex, err
+	return &Script{
+		Type:       scriptType,
+		M:          m,
+		N:          n,
+		PublicKeys: publicKeys,
+		Sorted:     sortKeys,
+		Multisig:   redeemScript,
+		Address:    address,
+	}, nil
+}
+
+// sortPublicKeys sort serialized public keys lexicographically, as BIP67 defines
+func sortPublicKeys(publicKeys [][]byte) {
+	sort.Slice(publicKeys, func(i, j int) bool {
+		return bytes.Compare(publicKeys[i], publicKeys[j]) < 0
+	})
 }
 
 func newMOfNRedeemScript(m int, n int, publicKeys [][]byte) ([]byte, error) {
 	// validate inputs
-	if n < 1 || n > 7 {
+	if n < 1 || n > maxPublicKeys {
 		return nil, ErrNRange
 	}
 	if m < 1 || m > n {
@@ -109,6 +181,9 @@ func newMOfNRedeemScript(m int, n int, publicKeys [][]byte) ([]byte, error) {
 	}
 	redeemScript.WriteByte(byte(nOPCode))
 	redeemScript.WriteByte(byte(OP_CHECKMULTISIG))
+	if redeemScript.Len() > maxRedeemScriptSize {
+		return nil, ErrScriptSize
+	}
 	return redeemScript.Bytes(), nil
 }
 
@@ -128,16 +203,21 @@ func hash160(data []byte) ([]byte, error) {
 	return ripemd160Hash.Sum(nil), nil
 }
 
-// isPublicKeyValid validate publicKey
+// isPublicKeyValid validate publicKey, either 33 bytes compressed (0x02/0x03) or 65 bytes uncompressed (0x04),
+// and a point on the secp256k1 curve
 func isPublicKeyValid(publicKey []byte) error {
 	switch {
-	case publicKey == nil:
+	case len(publicKey) == 0:
 		return ErrEmptyPubKey
-	case len(publicKey) != 65:
+	case len(publicKey) == 33 && (publicKey[0] == byte(2) || publicKey[0] == byte(3)):
+	case len(publicKey) == 65 && publicKey[0] == byte(4):
+	default:
 		return ErrInvalidPubKey
-	case publicKey[0] != byte(4):
+	}
+
+	if _, err := btcec.ParsePubKey(publicKey, btcec.S256()); err != nil {
 		return ErrInvalidPubKey
-	default:
-		return nil
 	}
+
+	return nil
 }
@@ -2,10 +2,34 @@ package multisig
 
 import (
 	"encoding/hex"
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
 
+var (
+	uncompressedKey1 = "04a882d414e478039cd5b52a92ffb13dd5e6bd4515497439dffd691a0f12af9575fa349b5694ed3155b136f09e63975a1700c9f4d4df849323dac06cf3bd6458cd"
+	uncompressedKey2 = "046ce31db9bdd543e72fe3039a1f1c047dab87037c36a669ff90e28da1848f640de68c2fe913d363a51154a0c62d7adea1b822d05035077418267b1a1379790187"
+	uncompressedKey3 = "0411ffd36c70776538d079fbae117dc38effafb33304af83ce4894589747aee1ef992f63280567f52f5ba870678b4ab4ff6c8ea600bd217870a8b4f1f09f3a8e83"
+	compressedKey1   = "03a882d414e478039cd5b52a92ffb13dd5e6bd4515497439dffd691a0f12af9575"
+	compressedKey2   = "036ce31db9bdd543e72fe3039a1f1c047dab87037c36a669ff90e28da1848f640d"
+	compressedKey3   = "0311ffd36c70776538d079fbae117dc38effafb33304af83ce4894589747aee1ef"
+)
+
+// testPublicKeys give count distinct valid public keys, serialized compressed or not
+func testPublicKeys(count int, compressed bool) [][]byte {
+	var keys [][]byte
+	for i := 1; i <= count; i++ {
+		_, publicKey := btcec.PrivKeyFromBytes(btcec.S256(), []byte{byte(i)})
+		if compressed {
+			keys = append(keys, publicKey.SerializeCompressed())
+		} else {
+			keys = append(keys, publicKey.SerializeUncompressed())
+		}
+	}
+	return keys
+}
+
 func TestIsPublicKeyValid(t *testing.T) {
 	var pubKey []byte
 	var err = isPublicKeyValid(pubKey)
@@ -28,7 +52,27 @@ func TestIsPublicKeyValid(t *testing.T) {
 	pubKey[0] = byte(4)
 	err = isPublicKeyValid(pubKey)
 
-	assert.NoError(t, err, "Expected no error: valid key")
+	assert.Error(t, err, "Expected error: public key not on the curve")
+
+	pubKey, _ = hex.DecodeString(uncompressedKey1)
+	err = isPublicKeyValid(pubKey)
+
+	assert.NoError(t, err, "Expected no error: valid uncompressed key")
+
+	pubKey, _ = hex.DecodeString(compressedKey1)
+	err = isPublicKeyValid(pubKey)
+
+	assert.NoError(t, err, "Expected no error: valid compressed key")
+
+	pubKey[0] = byte(4)
+	err = isPublicKeyValid(pubKey)
+
+	assert.Error(t, err, "Expected error: compressed key with uncompressed prefix")
+
+	pubKey, _ = hex.DecodeString("02" + "0000000000000000000000000000000000000000000000000000000000000000")
+	err = isPublicKeyValid(pubKey)
+
+	assert.Error(t, err, "Expected error: x coordinate not on the curve")
 }
 
 func TestHash160(t *testing.T) {
@@ -41,18 +85,13 @@ func TestHash160(t *testing.T) {
 }
 
 func TestNewMOfNRedeemScript(t *testing.T) {
-	var key1 = []byte{byte(4)}
-	var key2 = []byte{byte(4)}
-	var key3 = []byte{byte(4)}
-	for i := 0; i < 64; i++ {
-		key1 = append(key1, byte(1))
-		key2 = append(key2, byte(2))
-		key3 = append(key3, byte(3))
-	}
+	var key1, _ = hex.DecodeString(uncompressedKey1)
+	var key2, _ = hex.DecodeString(uncompressedKey2)
+	var key3, _ = hex.DecodeString(uncompressedKey3)
 	var pubKeys = [][]byte{}
 	pubKeys = append(pubKeys, key1, key2, key3)
 
-	var _, err = newMOfNRedeemScript(4, 8, pubKeys)
+	var _, err = newMOfNRedeemScript(4, 16, pubKeys)
 
 	assert.Error(t, err, "Expected error: Invalid n value")
 
@@ -70,29 +109,55 @@ func TestNewMOfNRedeemScript(t *testing.T) {
 
 	s, err := newMOfNRedeemScript(1, 3, pubKeys)
 
-	var expected = []byte{0x51, 0x41, 0x4, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x1, 0x41, 0x4, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x2, 0x41, 0x4, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x3, 0x53, 0xae}
+	var expected = "5141" + uncompressedKey1 + "41" + uncompressedKey2 + "41" + uncompressedKey3 + "53ae"
 
 	assert.NoError(t, err, "Expected no error: correct script")
-	assert.Equal(t, expected, s, "Incorrect script generated")
+	assert.Equal(t, expected, hex.EncodeToString(s), "Incorrect script generated")
+}
+
+func TestNewMOfNRedeemScript_ScriptSize(t *testing.T) {
+	var s, err = newMOfNRedeemScript(15, 15, testPublicKeys(15, true))
+
+	assert.NoError(t, err, "Expected no error: 15-of-15 compressed keys fit in 520 bytes")
+	assert.Len(t, s, 513, "Incorrect script size")
+
+	_, err = newMOfNRedeemScript(8, 8, testPublicKeys(8, false))
+
+	assert.Equal(t, ErrScriptSize, err, "Expected error: 8 uncompressed keys exceed 520 bytes")
+
+	s, err = newMOfNRedeemScript(7, 7, testPublicKeys(7, false))
+
+	assert.NoError(t, err, "Expected no error: 7 uncompressed keys fit in 520 bytes")
+	assert.Len(t, s, 465, "Incorrect script size")
 }
 
 func TestGenerateAddress(t *testing.T) {
-	var key1 = []byte{byte(4)}
-	var key2 = []byte{byte(4)}
-	var key3 = []byte{byte(4)}
-	for i := 0; i < 64; i++ {
-		key1 = append(key1, byte(1))
-		key2 = append(key2, byte(2))
-		key3 = append(key3, byte(3))
-	}
-	var pubKeys []string
-	pubKeys = append(pubKeys, hex.EncodeToString(key1), hex.EncodeToString(key2), hex.EncodeToString(key3))
+	var pubKeys = []string{uncompressedKey1, uncompressedKey2, uncompressedKey3}
 
 	var a, s, err = GenerateAddress(2, 3, pubKeys)
-	var expectedA = "36TMYGm2uu6eDQ43KZD7MYtiq8NZGHBSui"
-	var expectedS = "5241040101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010141040202020202020202020202020202020202020202020202020202020202020202020202020202020202020202020202020202020202020202020202020202020241040303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030353ae"
+	var expectedA = "347N1Thc213QqfYCz3PZkjoJpNv5b14kBd"
+	var expectedS = "5241" + uncompressedKey1 + "41" + uncompressedKey2 + "41" + uncompressedKey3 + "53ae"
 
 	assert.NoError(t, err, "Expected no error: correct address and script")
 	assert.Equal(t, expectedA, a, "Incorrect address generated")
 	assert.Equal(t, expectedS, s, "Incorrect script generated")
 }
+
+func TestGenerateAddress_Compressed(t *testing.T) {
+	var pubKeys = []string{compressedKey1, compressedKey2, compressedKey3}
+
+	var a, s, err = GenerateAddress(2, 3, pubKeys)
+	var expectedA = "3MsXykid1v9i3FWP4Fbj8vVLKrjPbBQCbP"
+	var expectedS = "5221" + compressedKey1 + "21" + compressedKey2 + "21" + compressedKey3 + "53ae"
+
+	assert.NoError(t, err, "Expected no error: correct address and script")
+	assert.Equal(t, expectedA, a, "Incorrect address generated")
+	assert.Equal(t, expectedS, s, "Incorrect script generated")
+
+	pubKeys = []string{uncompressedKey1, compressedKey2, compressedKey3}
+
+	a, _, err = GenerateAddress(2, 3, pubKeys)
+
+	assert.NoError(t, err, "Expected no error: mixed key formats")
+	assert.Equal(t, "36W6qrKySYEQnasdASqirnR3BA51PJhLUg", a, "Incorrect address generated")
+}
@@ -0,0 +1,120 @@
+package multisig
+
+import (
+	"crypto/sha256"
+	"errors"
+	"strings"
+
+	"btcwalletapi/cryto/bech32m"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcutil/base58"
+)
+
+// ScriptType how the multisig script is committed to by the output
+type ScriptType string
+
+const (
+	// ScriptTypeP2SH legacy pay to script hash
+	ScriptTypeP2SH ScriptType = "p2sh"
+	// ScriptTypeP2SHP2WSH pay to witness script hash nested in a pay to script hash
+	ScriptTypeP2SHP2WSH ScriptType = "p2sh-p2wsh"
+	// ScriptTypeP2WSH native segwit pay to witness script hash
+	ScriptTypeP2WSH ScriptType = "p2wsh"
+)
+
+const (
+	// OP_0 witness version 0
+	OP_0 = 0
+	// OP_DATA_32 push of the next 32 bytes
+	OP_DATA_32 = 32
+)
+
+var (
+	ErrUnsupportedScriptType  = errors.New("unsupported script type, expected p2sh, p2sh-p2wsh or p2wsh")
+	ErrUncompressedWitnessKey = errors.New("segwit multisig only accepts compressed public keys")
+)
+
+// ParseScriptType resolve a script type name, an empty name selects legacy P2SH
+func ParseScriptType(name string) (ScriptType, error) {
+	switch scriptType := ScriptType(strings.ToLower(strings.TrimSpace(name))); scriptType {
+	case "":
+		return ScriptTypeP2SH, nil
+	case ScriptTypeP2SH, ScriptTypeP2SHP2WSH, ScriptTypeP2WSH:
+		return scriptType, nil
+	default:
+		return "", ErrUnsupportedScriptType
+	}
+}
+
+// isWitness tell whether the script is spent from the witness
+func (s ScriptType) isWitness() bool {
+	return s == ScriptTypeP2SHP2WSH || s == ScriptTypeP2WSH
+}
+
+// witnessProgram give the version 0 witness program of a witness script, its SHA256
+func witnessProgram(witnessScript []byte) []byte {
+	hash := sha256.Sum256(witnessScript)
+	return hash[:]
+}
+
+// witnessRedeemScript give the P2SH redeem script wrapping a version 0 witness program: OP_0 <32 bytes program>
+func witnessRedeemScript(program []byte) []byte {
+	return append([]byte{OP_0, OP_DATA_32}, program...)
+}
+
+// scriptAddress encode the address paying to a multisig script on a network
+func scriptAddress(script []byte, scriptType ScriptType, network *chaincfg.Params) (string, error) {
+	switch scriptType {
+	case ScriptTypeP2SH:
+		scriptHash, err := hash160(script)
+		if err != nil {
+			return "", err
+		}
+		return base58.CheckEncode(scriptHash, network.ScriptHashAddrID), nil
+	case ScriptTypeP2SHP2WSH:
+		scriptHash, err := hash160(witnessRedeemScript(witnessProgram(script)))
+		if err != nil {
+			return "", err
+		}
+		return base58.CheckEncode(scriptHash, network.ScriptHashAddrID), nil
+	case ScriptTypeP2WSH:
+		return bech32m.EncodeSegWitAddress(network.Bech32HRPSegwit, 0, witnessProgram(script))
+	default:
+		return "", ErrUnsupportedScriptType
+	}
+}
+
+// RedeemScript give the script revealed in the P2SH spending input, none for P2WSH
+func (s *Script) RedeemScript() []byte {
+	switch s.Type {
+	case ScriptTypeP2SH:
+		return s.Multisig
+	case ScriptTypeP2SHP2WSH:
+		return witnessRedeemScript(witnessProgram(s.Multisig))
+	default:
+		return nil
+	}
+}
+
+// WitnessScript give the script revealed in the witness, none for legacy P2SH
+func (s *Script) WitnessScript() []byte {
+	if !s.Type.isWitness() {
+		return nil
+	}
+	return s.Multisig
+}
+
+// ScriptHash give the hash committed to by the output, the HASH160 of the redeem script for P2SH
+// and P2SH-P2WSH or the SHA256 of the witness script for P2WSH
+func (s *Script) ScriptHash() ([]byte, error) {
+	if s.Type == ScriptTypeP2WSH {
+		return witnessProgram(s.Multisig), nil
+	}
+	return hash160(s.RedeemScript())
+}
+
+// ASM disassemble the multisig script
+func (s *Script) ASM() (string, error) {
+	return txscript.DisasmString(s.Multisig)
+}
@@ -0,0 +1,123 @@
+package multisig
+
+import (
+	"encoding/hex"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseScriptType(t *testing.T) {
+	var scriptType, err = ParseScriptType("")
+
+	assert.NoError(t, err, "Expected no error: empty name selects p2sh")
+	assert.Equal(t, ScriptTypeP2SH, scriptType, "Incorrect script type")
+
+	scriptType, err = ParseScriptType("P2SH-P2WSH")
+
+	assert.NoError(t, err, "Expected no error: case insensitive name")
+	assert.Equal(t, ScriptTypeP2SHP2WSH, scriptType, "Incorrect script type")
+
+	_, err = ParseScriptType("p2tr")
+
+	assert.Equal(t, ErrUnsupportedScriptType, err, "Expected error: unsupported script type")
+}
+
+func TestGenerateScriptAddress(t *testing.T) {
+	var pubKeys = []string{compressedKey1, compressedKey2, compressedKey3}
+	var expectedS = "5221" + compressedKey1 + "21" + compressedKey2 + "21" + compressedKey3 + "53ae"
+
+	var s, err = GenerateScriptAddress(2, 3, pubKeys, ScriptTypeP2WSH, false, &chaincfg.MainNetParams)
+
+	assert.NoError(t, err, "Expected no error: correct address and script")
+	assert.Equal(t, "bc1qznadz5sy773nv3pr2n2jmymcyzkrn64tttz5640nz6988zmemzfs3dn6jt", s.Address, "Incorrect P2WSH address generated")
+	assert.Len(t, s.Address, 62, "Incorrect P2WSH address length")
+	assert.Equal(t, expectedS, hex.EncodeToString(s.Multisig), "Incorrect witness script generated")
+
+	s, err = GenerateScriptAddress(2, 3, pubKeys, ScriptTypeP2SHP2WSH, false, &chaincfg.MainNetParams)
+
+	assert.NoError(t, err, "Expected no error: correct address and script")
+	assert.Equal(t, "3F7ULEo5xPC4B9rkN3T8tWL8ZfTCvycAx4", s.Address, "Incorrect P2SH-P2WSH address generated")
+	assert.Equal(t, expectedS, hex.EncodeToString(s.Multisig), "Incorrect witness script generated")
+
+	s, err = GenerateScriptAddress(2, 3, pubKeys, ScriptTypeP2SH, false, &chaincfg.MainNetParams)
+
+	assert.NoError(t, err, "Expected no error: correct address and script")
+	assert.Equal(t, "3MsXykid1v9i3FWP4Fbj8vVLKrjPbBQCbP", s.Address, "Incorrect P2SH address generated")
+
+	s, err = GenerateScriptAddress(2, 3, pubKeys, ScriptTypeP2WSH, false, &chaincfg.TestNet3Params)
+
+	assert.NoError(t, err, "Expected no error: correct address and script")
+	assert.Equal(t, "tb1q", s.Address[:4], "Incorrect P2WSH testnet address generated")
+
+	s, err = GenerateScriptAddress(2, 3, pubKeys, ScriptTypeP2SH, false, &chaincfg.TestNet3Params)
+
+	assert.NoError(t, err, "Expected no error: correct address and script")
+	assert.Equal(t, "2", s.Address[:1], "Incorrect P2SH testnet address generated")
+
+	_, err = GenerateScriptAddress(2, 3, []string{uncompressedKey1, compressedKey2, compressedKey3}, ScriptTypeP2WSH, false, &chaincfg.MainNetParams)
+
+	assert.Equal(t, ErrUncompressedWitnessKey, err, "Expected error: uncompressed key in witness script")
+}
+
+func TestGenerateScriptAddress_SortKeys(t *testing.T) {
+	// BIP67 test vector 1
+	var pubKeys = []string{
+		"02ff12471208c14bd580709cb2358d98975247d8765f92bc25eab3b2763ed605f8",
+		"02fe6f0a5a297eb38c391581c4413e084773ea23954d93f7753db7dc0adc188b2f",
+	}
+
+	var s, err = GenerateScriptAddress(2, 2, pubKeys, ScriptTypeP2SH, true, &chaincfg.MainNetParams)
+
+	assert.NoError(t, err, "Expected no error: correct address and script")
+	assert.Equal(t, "39bgKC7RFbpoCRbtD5KEdkYKtNyhpsNa3Z", s.Address, "Incorrect address generated")
+	assert.Equal(t, "522102fe6f0a5a297eb38c391581c4413e084773ea23954d93f7753db7dc0adc188b2f2102ff12471208c14bd580709cb2358d98975247d8765f92bc25eab3b2763ed605f852ae", hex.EncodeToString(s.Multisig), "Incorrect script generated")
+	assert.Equal(t, pubKeys[1], hex.EncodeToString(s.PublicKeys[0]), "Incorrect key order")
+	assert.True(t, s.Sorted, "Expected sorted keys")
+
+	var reversed, _ = GenerateScriptAddress(2, 2, []string{pubKeys[1], pubKeys[0]}, ScriptTypeP2SH, true, &chaincfg.MainNetParams)
+
+	assert.Equal(t, s.Address, reversed.Address, "Expected the same address whatever the key order")
+}
+
+func TestGenerateScriptAddress_SortUncompressedKeys(t *testing.T) {
+	var pubKeys = []string{uncompressedKey1, compressedKey2, compressedKey3}
+
+	var _, err = GenerateScriptAddress(2, 3, pubKeys, ScriptTypeP2SH, true, &chaincfg.MainNetParams)
+
+	assert.Equal(t, ErrUncompressedSortedKey, err, "Expected error: BIP67 sorting of an uncompressed key")
+
+	_, err = GenerateScriptAddress(2, 3, pubKeys, ScriptTypeP2SH, false, &chaincfg.MainNetParams)
+
+	assert.NoError(t, err, "Expected no error: unsorted uncompressed keys")
+}
+
+func TestScript_Scripts(t *testing.T) {
+	var pubKeys = []string{compressedKey1, compressedKey2, compressedKey3}
+	var multisigScript = "5221" + compressedKey1 + "21" + compressedKey2 + "21" + compressedKey3 + "53ae"
+
+	var s, _ = GenerateScriptAddress(2, 3, pubKeys, ScriptTypeP2SH, false, &chaincfg.MainNetParams)
+	var scriptHash, err = s.ScriptHash()
+
+	assert.NoError(t, err, "Expected no error: valid script hash")
+	assert.Equal(t, multisigScript, hex.EncodeToString(s.RedeemScript()), "Incorrect redeem script")
+	assert.Nil(t, s.WitnessScript(), "Expected no witness script for legacy P2SH")
+	assert.Len(t, scriptHash, 20, "Incorrect script hash length")
+
+	asm, err := s.ASM()
+
+	assert.NoError(t, err, "Expected no error: valid script")
+	assert.Equal(t, "2 "+compressedKey1+" "+compressedKey2+" "+compressedKey3+" 3 OP_CHECKMULTISIG", asm, "Incorrect ASM")
+
+	s, _ = GenerateScriptAddress(2, 3, pubKeys, ScriptTypeP2WSH, false, &chaincfg.MainNetParams)
+	scriptHash, _ = s.ScriptHash()
+
+	assert.Nil(t, s.RedeemScript(), "Expected no redeem script for P2WSH")
+	assert.Equal(t, multisigScript, hex.EncodeToString(s.WitnessScript()), "Incorrect witness script")
+	assert.Len(t, scriptHash, 32, "Incorrect script hash length")
+
+	s, _ = GenerateScriptAddress(2, 3, pubKeys, ScriptTypeP2SHP2WSH, false, &chaincfg.MainNetParams)
+
+	assert.Equal(t, "0020"+hex.EncodeToString(witnessProgram(s.Multisig)), hex.EncodeToString(s.RedeemScript()), "Incorrect nested redeem script")
+	assert.Equal(t, multisigScript, hex.EncodeToString(s.WitnessScript()), "Incorrect witness script")
+}
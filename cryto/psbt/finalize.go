@@ -0,0 +1,227 @@
+package psbt
+
+import (
+	"bytes"
+
+	"btcwalletapi/cryto/multisig"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+// Combine merge PSBTs of the same unsigned transaction into the first one, the signatures, scripts,
+// derivations and unknown pairs missing from it are taken from the others
+func Combine(packets []*Packet) (*Packet, error) {
+	if len(packets) == 0 {
+		return nil, ErrMissingUnsignedTx
+	}
+
+	var result = packets[0]
+	for _, p := range packets[1:] {
+		if p.UnsignedTx.TxHash() != result.UnsignedTx.TxHash() {
+			return nil, ErrTxMismatch
+		}
+
+		for i := range result.Inputs {
+			result.Inputs[i].merge(&p.Inputs[i])
+		}
+		for i := range result.Outputs {
+			result.Outputs[i].merge(&p.Outputs[i])
+		}
+		result.Unknowns = mergeUnknowns(result.Unknowns, p.Unknowns)
+	}
+
+	return result, nil
+}
+
+func (in *Input) merge(other *Input) {
+	if in.NonWitnessUTXO == nil {
+		in.NonWitnessUTXO = other.NonWitnessUTXO
+	}
+	if in.WitnessUTXO == nil {
+		in.WitnessUTXO = other.WitnessUTXO
+	}
+	for _, sig := range other.PartialSigs {
+		if in.signature(sig.PublicKey) == nil {
+			in.PartialSigs = append(in.PartialSigs, sig)
+		}
+	}
+	if in.SighashType == 0 {
+		in.SighashType = other.SighashType
+	}
+	if in.RedeemScript == nil {
+		in.RedeemScript = other.RedeemScript
+	}
+	if in.WitnessScript == nil {
+		in.WitnessScript = other.WitnessScript
+	}
+	for _, derivation := range other.Derivations {
+		in.Derivations = addDerivation(in.Derivations, derivation)
+	}
+	if in.FinalScriptSig == nil {
+		in.FinalScriptSig = other.FinalScriptSig
+	}
+	if in.FinalScriptWitness == nil {
+		in.FinalScriptWitness = other.FinalScriptWitness
+	}
+	in.Unknowns = mergeUnknowns(in.Unknowns, other.Unknowns)
+}
+
+func (out *Output) merge(other *Output) {
+	if out.RedeemScript == nil {
+		out.RedeemScript = other.RedeemScript
+	}
+	if out.WitnessScript == nil {
+		out.WitnessScript = other.WitnessScript
+	}
+	for _, derivation := range other.Derivations {
+		out.Derivations = addDerivation(out.Derivations, derivation)
+	}
+	out.Unknowns = mergeUnknowns(out.Unknowns, other.Unknowns)
+}
+
+// mergeUnknowns add the unknown pairs whose key is missing
+func mergeUnknowns(unknowns, others []Unknown) []Unknown {
+	for _, other := range others {
+		var found bool
+		for _, u := range unknowns {
+			if bytes.Equal(u.Key, other.Key) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			unknowns = append(unknowns, other)
+		}
+	}
+	return unknowns
+}
+
+// Finalize build the final scriptSig and witness of every input holding enough partial signatures,
+// dropping the signing data as BIP174 finalizers do, inputs missing signatures are left as they are
+func Finalize(p *Packet) error {
+	for i := range p.Inputs {
+		if p.Inputs[i].Finalized() {
+			continue
+		}
+		if err := p.finalizeInput(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Packet) finalizeInput(index int) error {
+	var input = &p.Inputs[index]
+	prevOut, err := p.prevOut(index)
+	if err != nil {
+		return err
+	}
+	script, err := input.spentScript(prevOut.PkScript)
+	if err != nil {
+		return err
+	}
+
+	var builder = txscript.NewScriptBuilder()
+	var witness wire.TxWitness
+	switch {
+	case txscript.IsPayToWitnessPubKeyHash(script):
+		publicKey, signature := input.keyHashSignature(script[2:])
+		if signature == nil {
+			return nil
+		}
+		witness = wire.TxWitness{signature, publicKey}
+
+	case txscript.IsPayToWitnessScriptHash(script):
+		signatures, ok := input.multisigSignatures(input.WitnessScript)
+		if !ok {
+			return nil
+		}
+		// the empty dummy element CHECKMULTISIG pops
+		witness = append(wire.TxWitness{{}}, signatures...)
+		witness = append(witness, input.WitnessScript)
+
+	case isPayToPubKeyHash(script):
+		publicKey, signature := input.keyHashSignature(script[3:23])
+		if signature == nil {
+			return nil
+		}
+		builder.AddData(signature).AddData(publicKey)
+
+	default:
+		signatures, ok := input.multisigSignatures(script)
+		if !ok {
+			return nil
+		}
+		builder.AddOp(txscript.OP_0)
+		for _, signature := range signatures {
+			builder.AddData(signature)
+		}
+	}
+
+	if txscript.IsPayToScriptHash(prevOut.PkScript) {
+		builder.AddData(input.RedeemScript)
+	}
+	scriptSig, err := builder.Script()
+	if err != nil {
+		return err
+	}
+
+	input.FinalScriptSig = nil
+	if len(scriptSig) > 0 {
+		input.FinalScriptSig = scriptSig
+	}
+	input.FinalScriptWitness = witness
+	input.PartialSigs = nil
+	input.SighashType = 0
+	input.RedeemScript = nil
+	input.WitnessScript = nil
+	input.Derivations = nil
+
+	return nil
+}
+
+// keyHashSignature give the partial signature, with its public key, of the key hashing to a key hash
+func (in *Input) keyHashSignature(keyHash []byte) ([]byte, []byte) {
+	for _, sig := range in.PartialSigs {
+		if bytes.Equal(btcutil.Hash160(sig.PublicKey), keyHash) {
+			return sig.PublicKey, sig.Signature
+		}
+	}
+	return nil, nil
+}
+
+// multisigSignatures give m partial signatures ordered as the multisig script keys, false when there are fewer
+func (in *Input) multisigSignatures(script []byte) ([][]byte, bool) {
+	decoded, err := multisig.ParseScript(script)
+	if err != nil {
+		return nil, false
+	}
+
+	var signatures [][]byte
+	for _, publicKey := range decoded.PublicKeys {
+		if len(signatures) == decoded.M {
+			break
+		}
+		if signature := in.signature(publicKey); signature != nil {
+			signatures = append(signatures, signature)
+		}
+	}
+
+	return signatures, len(signatures) == decoded.M
+}
+
+// Extract give the network ready transaction of a complete PSBT
+func Extract(p *Packet) (*wire.MsgTx, error) {
+	if !p.Complete() {
+		return nil, ErrNotFinalized
+	}
+
+	var tx = p.UnsignedTx.Copy()
+	for i, input := range p.Inputs {
+		tx.TxIn[i].SignatureScript = input.FinalScriptSig
+		tx.TxIn[i].Witness = input.FinalScriptWitness
+	}
+
+	return tx, nil
+}
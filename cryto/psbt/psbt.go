@@ -0,0 +1,333 @@
+package psbt
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"sort"
+
+	"btcwalletapi/cryto/segwit"
+	"btcwalletapi/cryto/transaction"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+var (
+	ErrInvalidPSBT           = errors.New("invalid PSBT serialization")
+	ErrInvalidMagic          = errors.New("PSBT does not start with the psbt magic bytes")
+	ErrDuplicateKey          = errors.New("PSBT map holds a duplicate key")
+	ErrInvalidKey            = errors.New("PSBT key is malformed for its type")
+	ErrMissingUnsignedTx     = errors.New("PSBT has no unsigned transaction")
+	ErrInvalidUnsignedTx     = errors.New("PSBT unsigned transaction has a scriptSig or a witness")
+	ErrInputIndex            = errors.New("PSBT has no input at the index")
+	ErrOutputIndex           = errors.New("PSBT has no output at the index")
+	ErrMissingUTXO           = errors.New("PSBT input has no UTXO")
+	ErrMissingNonWitnessUTXO = errors.New("legacy input needs its previous transaction")
+	ErrPreviousTxMismatch    = errors.New("previous transaction does not match the input outpoint")
+	ErrMissingRedeemScript   = errors.New("P2SH input needs its redeem script")
+	ErrRedeemScriptMismatch  = errors.New("redeem script does not match the P2SH scriptPubKey")
+	ErrMissingWitnessScript  = errors.New("P2WSH input needs its witness script")
+	ErrWitnessScriptMismatch = errors.New("witness script does not match the P2WSH program")
+	ErrKeyMismatch           = errors.New("key does not own the script")
+	ErrTxMismatch            = errors.New("PSBTs do not share the same unsigned transaction")
+	ErrNotFinalized          = errors.New("PSBT has inputs not finalized")
+)
+
+// PartialSig signature of an input by one of its keys, the sighash type appended
+type PartialSig struct {
+	PublicKey []byte
+	Signature []byte
+}
+
+// Derivation BIP32 origin of a public key, the master key fingerprint and the absolute path
+type Derivation struct {
+	PublicKey   []byte
+	Fingerprint []byte
+	Path        []uint32
+}
+
+// Unknown key-value pair of an unknown type, kept as is
+type Unknown struct {
+	Key   []byte
+	Value []byte
+}
+
+// Input per input map of a PSBT
+type Input struct {
+	NonWitnessUTXO *wire.MsgTx
+	WitnessUTXO    *wire.TxOut
+	PartialSigs    []PartialSig
+	// SighashType sighash signatures must use, 0 when unset and SIGHASH_ALL is used
+	SighashType        txscript.SigHashType
+	RedeemScript       []byte
+	WitnessScript      []byte
+	Derivations        []Derivation
+	FinalScriptSig     []byte
+	FinalScriptWitness wire.TxWitness
+	Unknowns           []Unknown
+}
+
+// Output per output map of a PSBT
+type Output struct {
+	RedeemScript  []byte
+	WitnessScript []byte
+	Derivations   []Derivation
+	Unknowns      []Unknown
+}
+
+// Packet a BIP174 partially signed bitcoin transaction
+type Packet struct {
+	UnsignedTx *wire.MsgTx
+	Inputs     []Input
+	Outputs    []Output
+	Unknowns   []Unknown
+}
+
+// New build a PSBT of an unsigned transaction, segwit inputs carry their witness UTXO, P2SH inputs their redeem script
+// and P2WSH or P2SH-P2WSH inputs their witness script, legacy P2PKH and P2SH inputs need SetNonWitnessUTXO
+func New(tx *transaction.UnsignedTransaction) (*Packet, error) {
+	var p = &Packet{
+		UnsignedTx: tx.Tx.Copy(),
+		Inputs:     make([]Input, len(tx.Tx.TxIn)),
+		Outputs:    make([]Output, len(tx.Tx.TxOut)),
+	}
+	if len(tx.Inputs) != len(p.Inputs) {
+		return nil, ErrInputIndex
+	}
+
+	for i, utxo := range tx.Inputs {
+		var input = &p.Inputs[i]
+		var legacy = isPayToPubKeyHash(utxo.ScriptPubKey) ||
+			len(utxo.WitnessScript) == 0 && len(utxo.RedeemScript) > 0 && !txscript.IsWitnessProgram(utxo.RedeemScript)
+		if !legacy {
+			input.WitnessUTXO = wire.NewTxOut(utxo.Value, utxo.ScriptPubKey)
+		}
+		if len(utxo.RedeemScript) > 0 {
+			input.RedeemScript = utxo.RedeemScript
+		}
+		if len(utxo.WitnessScript) == 0 {
+			continue
+		}
+
+		input.WitnessScript = utxo.WitnessScript
+		if txscript.IsPayToScriptHash(utxo.ScriptPubKey) {
+			input.RedeemScript = witnessScriptHashScript(utxo.WitnessScript)
+		}
+	}
+
+	return p, nil
+}
+
+// isPayToPubKeyHash tell whether a script is a P2PKH OP_DUP OP_HASH160 <20 bytes> OP_EQUALVERIFY OP_CHECKSIG
+func isPayToPubKeyHash(script []byte) bool {
+	return txscript.GetScriptClass(script) == txscript.PubKeyHashTy
+}
+
+// witnessScriptHashScript give the P2WSH program script OP_0 <sha256(witness script)>
+func witnessScriptHashScript(witnessScript []byte) []byte {
+	program := sha256.Sum256(witnessScript)
+	return append([]byte{txscript.OP_0, txscript.OP_DATA_32}, program[:]...)
+}
+
+// witnessPubKeyHashScript give the P2WPKH program script OP_0 <hash160(public key)>
+func witnessPubKeyHashScript(publicKey []byte) []byte {
+	return append([]byte{txscript.OP_0, txscript.OP_DATA_20}, btcutil.Hash160(publicKey)...)
+}
+
+// SetNonWitnessUTXO attach the previous transaction of an input, it must hold the outpoint the input spends
+func (p *Packet) SetNonWitnessUTXO(index int, prevTx *wire.MsgTx) error {
+	if index < 0 || index >= len(p.Inputs) {
+		return ErrInputIndex
+	}
+
+	outPoint := p.UnsignedTx.TxIn[index].PreviousOutPoint
+	if prevTx.TxHash() != outPoint.Hash || int(outPoint.Index) >= len(prevTx.TxOut) {
+		return ErrPreviousTxMismatch
+	}
+
+	var input = &p.Inputs[index]
+	if input.WitnessUTXO != nil {
+		prevOut := prevTx.TxOut[outPoint.Index]
+		if prevOut.Value != input.WitnessUTXO.Value || !bytes.Equal(prevOut.PkScript, input.WitnessUTXO.PkScript) {
+			return ErrPreviousTxMismatch
+		}
+	}
+	input.NonWitnessUTXO = prevTx
+
+	return nil
+}
+
+// prevOut give the output an input spends, from the witness UTXO or the previous transaction
+func (p *Packet) prevOut(index int) (*wire.TxOut, error) {
+	var input = &p.Inputs[index]
+	if input.WitnessUTXO != nil {
+		return input.WitnessUTXO, nil
+	}
+	if input.NonWitnessUTXO == nil {
+		return nil, ErrMissingUTXO
+	}
+
+	outPoint := p.UnsignedTx.TxIn[index].PreviousOutPoint
+	if input.NonWitnessUTXO.TxHash() != outPoint.Hash || int(outPoint.Index) >= len(input.NonWitnessUTXO.TxOut) {
+		return nil, ErrPreviousTxMismatch
+	}
+
+	return input.NonWitnessUTXO.TxOut[outPoint.Index], nil
+}
+
+// AddInputDerivation record the BIP32 derivation of the key at path of the key manager seed on an input,
+// the key must own the spent output and the P2SH-P2WPKH redeem script is filled in when missing
+func (p *Packet) AddInputDerivation(index int, km *segwit.KeyManager, path []uint32) error {
+	if index < 0 || index >= len(p.Inputs) {
+		return ErrInputIndex
+	}
+	prevOut, err := p.prevOut(index)
+	if err != nil {
+		return err
+	}
+
+	derivation, redeemScript, err := keyDerivation(km, path, prevOut.PkScript, p.Inputs[index].WitnessScript)
+	if err != nil {
+		return err
+	}
+
+	var input = &p.Inputs[index]
+	if input.RedeemScript == nil {
+		input.RedeemScript = redeemScript
+	}
+	input.Derivations = addDerivation(input.Derivations, *derivation)
+
+	return nil
+}
+
+// AddInputCosigner record the BIP32 derivation of a multisig cosigner key on an input,
+// the key must be one of the input witness or redeem script keys
+func (p *Packet) AddInputCosigner(index int, derivation Derivation) error {
+	if index < 0 || index >= len(p.Inputs) {
+		return ErrInputIndex
+	}
+
+	var input = &p.Inputs[index]
+	var script = input.WitnessScript
+	if script == nil {
+		script = input.RedeemScript
+	}
+	if !multisigHasKey(script, derivation.PublicKey) {
+		return ErrKeyMismatch
+	}
+	input.Derivations = addDerivation(input.Derivations, derivation)
+
+	return nil
+}
+
+// AddOutputDerivation record the BIP32 derivation of the key at path of the key manager seed on an output,
+// typically the change, the P2SH-P2WPKH redeem script is filled in when missing
+func (p *Packet) AddOutputDerivation(index int, km *segwit.KeyManager, path []uint32) error {
+	if index < 0 || index >= len(p.Outputs) {
+		return ErrOutputIndex
+	}
+
+	derivation, redeemScript, err := keyDerivation(km, path, p.UnsignedTx.TxOut[index].PkScript, p.Outputs[index].WitnessScript)
+	if err != nil {
+		return err
+	}
+
+	var output = &p.Outputs[index]
+	if output.RedeemScript == nil {
+		output.RedeemScript = redeemScript
+	}
+	output.Derivations = addDerivation(output.Derivations, *derivation)
+
+	return nil
+}
+
+// keyDerivation derive the key at path and match its compressed, then uncompressed, serialization against
+// a scriptPubKey, giving the P2SH-P2WPKH redeem script it needs
+func keyDerivation(km *segwit.KeyManager, path []uint32, scriptPubKey, witnessScript []byte) (*Derivation, []byte, error) {
+	key, err := km.KeyAt(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	fingerprint, err := km.MasterFingerprint()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, compress := range []bool{true, false} {
+		publicKey := key.PublicKey(compress)
+		redeemScript, ok := ownsScript(publicKey, scriptPubKey, witnessScript)
+		if ok {
+			return &Derivation{PublicKey: publicKey, Fingerprint: fingerprint, Path: path}, redeemScript, nil
+		}
+	}
+
+	return nil, nil, ErrKeyMismatch
+}
+
+// ownsScript tell whether a serialized public key can spend a scriptPubKey, giving the P2SH-P2WPKH redeem script
+func ownsScript(publicKey, scriptPubKey, witnessScript []byte) ([]byte, bool) {
+	switch {
+	case isPayToPubKeyHash(scriptPubKey):
+		return nil, bytes.Equal(btcutil.Hash160(publicKey), scriptPubKey[3:23])
+	case txscript.IsPayToWitnessPubKeyHash(scriptPubKey):
+		return nil, bytes.Equal(btcutil.Hash160(publicKey), scriptPubKey[2:])
+	case txscript.IsPayToWitnessScriptHash(scriptPubKey):
+		return nil, multisigHasKey(witnessScript, publicKey)
+	case txscript.IsPayToScriptHash(scriptPubKey):
+		if witnessScript != nil {
+			return nil, multisigHasKey(witnessScript, publicKey)
+		}
+		redeemScript := witnessPubKeyHashScript(publicKey)
+		return redeemScript, bytes.Equal(btcutil.Hash160(redeemScript), scriptPubKey[2:22])
+	}
+
+	return nil, false
+}
+
+// addDerivation add a derivation unless its public key is already there, keeping them sorted by public key
+func addDerivation(derivations []Derivation, derivation Derivation) []Derivation {
+	for _, d := range derivations {
+		if bytes.Equal(d.PublicKey, derivation.PublicKey) {
+			return derivations
+		}
+	}
+
+	derivations = append(derivations, derivation)
+	sort.Slice(derivations, func(i, j int) bool {
+		return bytes.Compare(derivations[i].PublicKey, derivations[j].PublicKey) < 0
+	})
+	return derivations
+}
+
+// Finalized tell whether the input has its final scriptSig or witness
+func (in *Input) Finalized() bool {
+	return in.FinalScriptSig != nil || in.FinalScriptWitness != nil
+}
+
+// Complete tell whether every input is finalized and the transaction can be extracted
+func (p *Packet) Complete() bool {
+	for i := range p.Inputs {
+		if !p.Inputs[i].Finalized() {
+			return false
+		}
+	}
+	return true
+}
+
+// Fee give the transaction fee, the spent outputs minus the outputs, every input needing its UTXO
+func (p *Packet) Fee() (int64, error) {
+	var result int64
+	for i := range p.Inputs {
+		prevOut, err := p.prevOut(i)
+		if err != nil {
+			return 0, err
+		}
+		result += prevOut.Value
+	}
+	for _, output := range p.UnsignedTx.TxOut {
+		result -= output.Value
+	}
+
+	return result, nil
+}
@@ -0,0 +1,402 @@
+package psbt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"sort"
+	"strings"
+
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// magic psbt followed by the 0xff separator
+var magic = []byte{0x70, 0x73, 0x62, 0x74, 0xff}
+
+// maxFieldSize bound of a single PSBT key or value
+const maxFieldSize = wire.MaxMessagePayload
+
+// global, input and output key types
+const (
+	globalUnsignedTx = 0x00
+
+	inputNonWitnessUTXO     = 0x00
+	inputWitnessUTXO        = 0x01
+	inputPartialSig         = 0x02
+	inputSighashType        = 0x03
+	inputRedeemScript       = 0x04
+	inputWitnessScript      = 0x05
+	inputBIP32Derivation    = 0x06
+	inputFinalScriptSig     = 0x07
+	inputFinalScriptWitness = 0x08
+
+	outputRedeemScript    = 0x00
+	outputWitnessScript   = 0x01
+	outputBIP32Derivation = 0x02
+)
+
+// Serialize give the BIP174 binary serialization, partial signatures and derivations sorted by public key
+func (p *Packet) Serialize() ([]byte, error) {
+	var w bytes.Buffer
+	w.Write(magic)
+
+	// global map
+	var tx bytes.Buffer
+	if err := p.UnsignedTx.SerializeNoWitness(&tx); err != nil {
+		return nil, err
+	}
+	writePair(&w, []byte{globalUnsignedTx}, tx.Bytes())
+	writeUnknowns(&w, p.Unknowns)
+	w.WriteByte(0x00)
+
+	for i := range p.Inputs {
+		if err := p.Inputs[i].serialize(&w); err != nil {
+			return nil, err
+		}
+	}
+	for i := range p.Outputs {
+		p.Outputs[i].serialize(&w)
+	}
+
+	return w.Bytes(), nil
+}
+
+// B64Encode give the base64 encoding of the serialization, the usual PSBT interchange form
+func (p *Packet) B64Encode() (string, error) {
+	serialized, err := p.Serialize()
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(serialized), nil
+}
+
+func (in *Input) serialize(w *bytes.Buffer) error {
+	if in.NonWitnessUTXO != nil {
+		var tx bytes.Buffer
+		if err := in.NonWitnessUTXO.Serialize(&tx); err != nil {
+			return err
+		}
+		writePair(w, []byte{inputNonWitnessUTXO}, tx.Bytes())
+	}
+	if in.WitnessUTXO != nil {
+		var txOut bytes.Buffer
+		if err := wire.WriteTxOut(&txOut, 0, 0, in.WitnessUTXO); err != nil {
+			return err
+		}
+		writePair(w, []byte{inputWitnessUTXO}, txOut.Bytes())
+	}
+
+	var sigs = append([]PartialSig(nil), in.PartialSigs...)
+	sort.Slice(sigs, func(i, j int) bool { return bytes.Compare(sigs[i].PublicKey, sigs[j].PublicKey) < 0 })
+	for _, sig := range sigs {
+		writePair(w, append([]byte{inputPartialSig}, sig.PublicKey...), sig.Signature)
+	}
+
+	if in.SighashType != 0 {
+		var value [4]byte
+		binary.LittleEndian.PutUint32(value[:], uint32(in.SighashType))
+		writePair(w, []byte{inputSighashType}, value[:])
+	}
+	if in.RedeemScript != nil {
+		writePair(w, []byte{inputRedeemScript}, in.RedeemScript)
+	}
+	if in.WitnessScript != nil {
+		writePair(w, []byte{inputWitnessScript}, in.WitnessScript)
+	}
+	writeDerivations(w, inputBIP32Derivation, in.Derivations)
+	if in.FinalScriptSig != nil {
+		writePair(w, []byte{inputFinalScriptSig}, in.FinalScriptSig)
+	}
+	if in.FinalScriptWitness != nil {
+		var witness bytes.Buffer
+		wire.WriteVarInt(&witness, 0, uint64(len(in.FinalScriptWitness)))
+		for _, item := range in.FinalScriptWitness {
+			wire.WriteVarBytes(&witness, 0, item)
+		}
+		writePair(w, []byte{inputFinalScriptWitness}, witness.Bytes())
+	}
+	writeUnknowns(w, in.Unknowns)
+	w.WriteByte(0x00)
+
+	return nil
+}
+
+func (out *Output) serialize(w *bytes.Buffer) {
+	if out.RedeemScript != nil {
+		writePair(w, []byte{outputRedeemScript}, out.RedeemScript)
+	}
+	if out.WitnessScript != nil {
+		writePair(w, []byte{outputWitnessScript}, out.WitnessScript)
+	}
+	writeDerivations(w, outputBIP32Derivation, out.Derivations)
+	writeUnknowns(w, out.Unknowns)
+	w.WriteByte(0x00)
+}
+
+// writePair write <key length> <key> <value length> <value>
+func writePair(w *bytes.Buffer, key, value []byte) {
+	wire.WriteVarBytes(w, 0, key)
+	wire.WriteVarBytes(w, 0, value)
+}
+
+// writeDerivations write derivations sorted by public key, the value is <fingerprint> <path components LE>
+func writeDerivations(w *bytes.Buffer, keyType byte, derivations []Derivation) {
+	var sorted = append([]Derivation(nil), derivations...)
+	sort.Slice(sorted, func(i, j int) bool { return bytes.Compare(sorted[i].PublicKey, sorted[j].PublicKey) < 0 })
+
+	for _, d := range sorted {
+		value := append([]byte(nil), d.Fingerprint...)
+		for _, component := range d.Path {
+			var buf [4]byte
+			binary.LittleEndian.PutUint32(buf[:], component)
+			value = append(value, buf[:]...)
+		}
+		writePair(w, append([]byte{keyType}, d.PublicKey...), value)
+	}
+}
+
+func writeUnknowns(w *bytes.Buffer, unknowns []Unknown) {
+	for _, u := range unknowns {
+		writePair(w, u.Key, u.Value)
+	}
+}
+
+// ParseBase64 decode a base64 encoded PSBT
+func ParseBase64(encoded string) (*Packet, error) {
+	serialized, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, ErrInvalidPSBT
+	}
+
+	return Parse(serialized)
+}
+
+// Parse decode a BIP174 binary serialization, unknown key types are kept
+func Parse(serialized []byte) (*Packet, error) {
+	if !bytes.HasPrefix(serialized, magic) {
+		return nil, ErrInvalidMagic
+	}
+	var r = bytes.NewReader(serialized[len(magic):])
+	var p Packet
+
+	// global map
+	err := readMap(r, func(key, value []byte) error {
+		if key[0] != globalUnsignedTx {
+			p.Unknowns = append(p.Unknowns, Unknown{Key: key, Value: value})
+			return nil
+		}
+		if len(key) != 1 {
+			return ErrInvalidKey
+		}
+
+		var tx wire.MsgTx
+		if err := tx.DeserializeNoWitness(bytes.NewReader(value)); err != nil {
+			return ErrInvalidUnsignedTx
+		}
+		for _, txIn := range tx.TxIn {
+			if len(txIn.SignatureScript) > 0 || len(txIn.Witness) > 0 {
+				return ErrInvalidUnsignedTx
+			}
+		}
+		p.UnsignedTx = &tx
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if p.UnsignedTx == nil {
+		return nil, ErrMissingUnsignedTx
+	}
+
+	p.Inputs = make([]Input, len(p.UnsignedTx.TxIn))
+	for i := range p.Inputs {
+		if err := p.Inputs[i].parse(r); err != nil {
+			return nil, err
+		}
+	}
+	p.Outputs = make([]Output, len(p.UnsignedTx.TxOut))
+	for i := range p.Outputs {
+		if err := p.Outputs[i].parse(r); err != nil {
+			return nil, err
+		}
+	}
+	if r.Len() != 0 {
+		return nil, ErrInvalidPSBT
+	}
+
+	return &p, nil
+}
+
+func (in *Input) parse(r *bytes.Reader) error {
+	return readMap(r, func(key, value []byte) error {
+		var keyData = key[1:]
+
+		switch key[0] {
+		case inputNonWitnessUTXO:
+			var tx wire.MsgTx
+			if len(keyData) != 0 || tx.Deserialize(bytes.NewReader(value)) != nil {
+				return ErrInvalidKey
+			}
+			in.NonWitnessUTXO = &tx
+		case inputWitnessUTXO:
+			txOut, err := parseTxOut(value)
+			if len(keyData) != 0 || err != nil {
+				return ErrInvalidKey
+			}
+			in.WitnessUTXO = txOut
+		case inputPartialSig:
+			if !isPublicKeySize(keyData) || len(value) == 0 {
+				return ErrInvalidKey
+			}
+			in.PartialSigs = append(in.PartialSigs, PartialSig{PublicKey: keyData, Signature: value})
+		case inputSighashType:
+			if len(keyData) != 0 || len(value) != 4 {
+				return ErrInvalidKey
+			}
+			in.SighashType = txscript.SigHashType(binary.LittleEndian.Uint32(value))
+		case inputRedeemScript:
+			if len(keyData) != 0 {
+				return ErrInvalidKey
+			}
+			in.RedeemScript = value
+		case inputWitnessScript:
+			if len(keyData) != 0 {
+				return ErrInvalidKey
+			}
+			in.WitnessScript = value
+		case inputBIP32Derivation:
+			derivation, err := parseDerivation(keyData, value)
+			if err != nil {
+				return err
+			}
+			in.Derivations = append(in.Derivations, *derivation)
+		case inputFinalScriptSig:
+			if len(keyData) != 0 {
+				return ErrInvalidKey
+			}
+			in.FinalScriptSig = value
+		case inputFinalScriptWitness:
+			witness, err := parseWitness(value)
+			if len(keyData) != 0 || err != nil {
+				return ErrInvalidKey
+			}
+			in.FinalScriptWitness = witness
+		default:
+			in.Unknowns = append(in.Unknowns, Unknown{Key: key, Value: value})
+		}
+		return nil
+	})
+}
+
+func (out *Output) parse(r *bytes.Reader) error {
+	return readMap(r, func(key, value []byte) error {
+		var keyData = key[1:]
+
+		switch key[0] {
+		case outputRedeemScript:
+			if len(keyData) != 0 {
+				return ErrInvalidKey
+			}
+			out.RedeemScript = value
+		case outputWitnessScript:
+			if len(keyData) != 0 {
+				return ErrInvalidKey
+			}
+			out.WitnessScript = value
+		case outputBIP32Derivation:
+			derivation, err := parseDerivation(keyData, value)
+			if err != nil {
+				return err
+			}
+			out.Derivations = append(out.Derivations, *derivation)
+		default:
+			out.Unknowns = append(out.Unknowns, Unknown{Key: key, Value: value})
+		}
+		return nil
+	})
+}
+
+// readMap read key-value pairs up to the 0x00 separator, rejecting duplicate keys
+func readMap(r *bytes.Reader, handle func(key, value []byte) error) error {
+	var seen = make(map[string]bool)
+
+	for {
+		key, err := wire.ReadVarBytes(r, 0, maxFieldSize, "key")
+		if err != nil {
+			return ErrInvalidPSBT
+		}
+		if len(key) == 0 {
+			return nil
+		}
+		value, err := wire.ReadVarBytes(r, 0, maxFieldSize, "value")
+		if err != nil {
+			return ErrInvalidPSBT
+		}
+
+		if seen[string(key)] {
+			return ErrDuplicateKey
+		}
+		seen[string(key)] = true
+
+		if err := handle(key, value); err != nil {
+			return err
+		}
+	}
+}
+
+// parseTxOut decode a serialized output, <value LE> <scriptPubKey length> <scriptPubKey>
+func parseTxOut(value []byte) (*wire.TxOut, error) {
+	if len(value) < 8 {
+		return nil, ErrInvalidPSBT
+	}
+	var r = bytes.NewReader(value[8:])
+	script, err := wire.ReadVarBytes(r, 0, maxFieldSize, "script")
+	if err != nil || r.Len() != 0 {
+		return nil, ErrInvalidPSBT
+	}
+
+	return wire.NewTxOut(int64(binary.LittleEndian.Uint64(value[:8])), script), nil
+}
+
+// parseWitness decode a serialized witness stack, <item count> then every item with its length
+func parseWitness(value []byte) (wire.TxWitness, error) {
+	var r = bytes.NewReader(value)
+	count, err := wire.ReadVarInt(r, 0)
+	if err != nil || count > uint64(len(value)) {
+		return nil, ErrInvalidPSBT
+	}
+
+	var witness = make(wire.TxWitness, count)
+	for i := range witness {
+		witness[i], err = wire.ReadVarBytes(r, 0, maxFieldSize, "witness")
+		if err != nil {
+			return nil, ErrInvalidPSBT
+		}
+	}
+	if r.Len() != 0 {
+		return nil, ErrInvalidPSBT
+	}
+
+	return witness, nil
+}
+
+// parseDerivation decode a BIP32 derivation, the key data being the public key
+func parseDerivation(keyData, value []byte) (*Derivation, error) {
+	if !isPublicKeySize(keyData) || len(value) < 4 || len(value)%4 != 0 {
+		return nil, ErrInvalidKey
+	}
+
+	var path = make([]uint32, 0, len(value)/4-1)
+	for i := 4; i < len(value); i += 4 {
+		path = append(path, binary.LittleEndian.Uint32(value[i:i+4]))
+	}
+
+	return &Derivation{PublicKey: keyData, Fingerprint: value[:4], Path: path}, nil
+}
+
+// isPublicKeySize tell whether data has the size of a compressed or uncompressed public key
+func isPublicKeySize(data []byte) bool {
+	return len(data) == 33 || len(data) == 65
+}
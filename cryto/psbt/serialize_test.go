@@ -0,0 +1,73 @@
+package psbt
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse_RoundTrip(t *testing.T) {
+	var f = newTestFixture(t)
+	var p = f.newTestPacket(t)
+	p.Unknowns = []Unknown{{Key: []byte{0xfc, 0x01}, Value: []byte{0x02}}}
+	_, _ = Sign(p, f.managers[0])
+	_ = Finalize(p)
+
+	encoded, err := p.B64Encode()
+	assert.NoError(t, err, "Expected no error: valid PSBT")
+
+	parsed, err := ParseBase64(encoded)
+	assert.NoError(t, err, "Expected no error: valid PSBT")
+
+	reencoded, _ := parsed.B64Encode()
+
+	assert.Equal(t, encoded, reencoded, "Expected the same serialization")
+	assert.Equal(t, p.UnsignedTx.TxHash(), parsed.UnsignedTx.TxHash(), "Incorrect unsigned transaction")
+	assert.Equal(t, p.Inputs[0].FinalScriptWitness, parsed.Inputs[0].FinalScriptWitness, "Incorrect final witness")
+	assert.Equal(t, p.Inputs[2].NonWitnessUTXO.TxHash(), parsed.Inputs[2].NonWitnessUTXO.TxHash(), "Incorrect previous transaction")
+	assert.Equal(t, p.Inputs[3].Derivations, parsed.Inputs[3].Derivations, "Incorrect derivations")
+	assert.Equal(t, p.Inputs[3].PartialSigs, parsed.Inputs[3].PartialSigs, "Incorrect partial signatures")
+	assert.Equal(t, p.Outputs[1].Derivations, parsed.Outputs[1].Derivations, "Incorrect change derivation")
+	assert.Equal(t, p.Unknowns, parsed.Unknowns, "Expected the unknown pair kept")
+}
+
+func TestParse_Errors(t *testing.T) {
+	var _, err = ParseBase64("not base64!")
+
+	assert.Equal(t, ErrInvalidPSBT, err, "Expected error: not base64")
+
+	_, err = Parse([]byte("psbt"))
+
+	assert.Equal(t, ErrInvalidMagic, err, "Expected error: no separator")
+
+	// empty global map
+	_, err = Parse(append(magic, 0x00))
+
+	assert.Equal(t, ErrMissingUnsignedTx, err, "Expected error: no unsigned transaction")
+
+	var f = newTestFixture(t)
+	serialized, _ := f.newTestPacket(t).Serialize()
+
+	_, err = Parse(serialized[:len(serialized)-1])
+
+	assert.Equal(t, ErrInvalidPSBT, err, "Expected error: truncated")
+
+	_, err = Parse(append(serialized, 0x00))
+
+	assert.Equal(t, ErrInvalidPSBT, err, "Expected error: trailing data")
+
+	// an unknown global pair twice
+	duplicated := append(append([]byte{}, magic...), 0x01, 0xfc, 0x01, 0x00, 0x01, 0xfc, 0x01, 0x00, 0x00)
+
+	_, err = Parse(duplicated)
+
+	assert.Equal(t, ErrDuplicateKey, err, "Expected error: duplicate key")
+
+	// BIP174 invalid vector, the unsigned transaction has a scriptSig
+	signedTx, _ := base64.StdEncoding.DecodeString("cHNidP8BAP0KAQIAAAACqwlJoIxa98SbghL0F+LxWrP1wz3PFTghqBOfh3pbe+QAAAAAakcwRAIgR1lmF5fAGwNrJZKJSGhiGDR9iYZLcZ4ff89X0eURZYcCIFMJ6r9Wqk2Ikf/REf3xM286KdqGbX+EhtdVRs7tr5MZASEDXNxh/HupccC1AaZGoqg7ECy0OIEhfKaC3Ibi1z+ogpL+////qwlJoIxa98SbghL0F+LxWrP1wz3PFTghqBOfh3pbe+QBAAAAAP7///8CYDvqCwAAAAAZdqkUdopAu9dAy+gdmI5x3ipNXHE5ax2IrI4kAAAAAAAAGXapFG9GILVT+glechue4O/p+gOcykWXiKwAAAAAAAA=")
+
+	_, err = Parse(signedTx)
+
+	assert.Equal(t, ErrInvalidUnsignedTx, err, "Expected error: unsigned transaction with a scriptSig")
+}
@@ -0,0 +1,160 @@
+package psbt
+
+import (
+	"bytes"
+	"crypto/sha256"
+
+	"btcwalletapi/cryto/multisig"
+	"btcwalletapi/cryto/segwit"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcutil"
+)
+
+// Sign add a partial signature to every input the key manager seed can sign, those with a BIP32 derivation
+// of the seed master fingerprint whose key owns the spent script, giving the number of signatures added.
+// Finalized, already signed and unsupported (taproot, non standard) inputs are left untouched
+func Sign(p *Packet, km *segwit.KeyManager) (int, error) {
+	fingerprint, err := km.MasterFingerprint()
+	if err != nil {
+		return 0, err
+	}
+
+	var signed int
+	var sigHashes = txscript.NewTxSigHashes(p.UnsignedTx)
+	for i := range p.Inputs {
+		var input = &p.Inputs[i]
+		if input.Finalized() {
+			continue
+		}
+
+		for _, derivation := range input.Derivations {
+			if !bytes.Equal(derivation.Fingerprint, fingerprint) || input.signature(derivation.PublicKey) != nil {
+				continue
+			}
+
+			key, err := km.KeyAt(derivation.Path)
+			if err != nil {
+				return signed, err
+			}
+			// another seed may share the fingerprint
+			if !bytes.Equal(key.PublicKey(len(derivation.PublicKey) == 33), derivation.PublicKey) {
+				continue
+			}
+
+			signature, err := p.signInput(i, sigHashes, key.PrivateKey(), derivation.PublicKey)
+			if err != nil {
+				return signed, err
+			}
+			if signature == nil {
+				continue
+			}
+
+			input.PartialSigs = append(input.PartialSigs, PartialSig{PublicKey: derivation.PublicKey, Signature: signature})
+			signed++
+		}
+	}
+
+	return signed, nil
+}
+
+// signInput sign an input with a private key, nil when the public key does not own the spent script
+func (p *Packet) signInput(index int, sigHashes *txscript.TxSigHashes, privateKey *btcec.PrivateKey, publicKey []byte) ([]byte, error) {
+	var input = &p.Inputs[index]
+	prevOut, err := p.prevOut(index)
+	if err != nil {
+		return nil, err
+	}
+	script, err := input.spentScript(prevOut.PkScript)
+	if err != nil {
+		return nil, err
+	}
+
+	var hashType = input.SighashType
+	if hashType == 0 {
+		hashType = txscript.SigHashAll
+	}
+
+	switch {
+	case txscript.IsPayToWitnessPubKeyHash(script):
+		if !bytes.Equal(btcutil.Hash160(publicKey), script[2:]) {
+			return nil, nil
+		}
+		return txscript.RawTxInWitnessSignature(p.UnsignedTx, sigHashes, index, prevOut.Value, script, hashType, privateKey)
+
+	case txscript.IsPayToWitnessScriptHash(script):
+		if !multisigHasKey(input.WitnessScript, publicKey) {
+			return nil, nil
+		}
+		return txscript.RawTxInWitnessSignature(p.UnsignedTx, sigHashes, index, prevOut.Value, input.WitnessScript, hashType, privateKey)
+
+	case isPayToPubKeyHash(script):
+		if input.NonWitnessUTXO == nil {
+			return nil, ErrMissingNonWitnessUTXO
+		}
+		if !bytes.Equal(btcutil.Hash160(publicKey), script[3:23]) {
+			return nil, nil
+		}
+		return txscript.RawTxInSignature(p.UnsignedTx, index, script, hashType, privateKey)
+
+	case multisigHasKey(script, publicKey):
+		if input.NonWitnessUTXO == nil {
+			return nil, ErrMissingNonWitnessUTXO
+		}
+		return txscript.RawTxInSignature(p.UnsignedTx, index, script, hashType, privateKey)
+	}
+
+	return nil, nil
+}
+
+// spentScript resolve the script signatures commit to, the redeem script of P2SH outputs,
+// the witness script of P2WSH programs being checked against them
+func (in *Input) spentScript(scriptPubKey []byte) ([]byte, error) {
+	var script = scriptPubKey
+	if txscript.IsPayToScriptHash(script) {
+		if in.RedeemScript == nil {
+			return nil, ErrMissingRedeemScript
+		}
+		if !bytes.Equal(btcutil.Hash160(in.RedeemScript), script[2:22]) {
+			return nil, ErrRedeemScriptMismatch
+		}
+		script = in.RedeemScript
+	}
+
+	if txscript.IsPayToWitnessScriptHash(script) {
+		if in.WitnessScript == nil {
+			return nil, ErrMissingWitnessScript
+		}
+		program := sha256.Sum256(in.WitnessScript)
+		if !bytes.Equal(program[:], script[2:]) {
+			return nil, ErrWitnessScriptMismatch
+		}
+	}
+
+	return script, nil
+}
+
+// signature give the partial signature of a public key, nil when it did not sign
+func (in *Input) signature(publicKey []byte) []byte {
+	for _, sig := range in.PartialSigs {
+		if bytes.Equal(sig.PublicKey, publicKey) {
+			return sig.Signature
+		}
+	}
+	return nil
+}
+
+// multisigHasKey tell whether a public key is one of the keys of a multisig script
+func multisigHasKey(script, publicKey []byte) bool {
+	decoded, err := multisig.ParseScript(script)
+	if err != nil {
+		return false
+	}
+
+	for _, key := range decoded.PublicKeys {
+		if bytes.Equal(key, publicKey) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,222 @@
+package psbt
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"btcwalletapi/cryto/multisig"
+	"btcwalletapi/cryto/segwit"
+	"btcwalletapi/cryto/transaction"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	// abandon ... about, the BIP84 test mnemonic
+	seedA, _ = hex.DecodeString("5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4")
+	// BIP32 test vectors 1 and 2
+	seedB, _ = hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	seedC, _ = hex.DecodeString("fffcf9f6f3f0edeae7e4e1dedbd8d5d2cfccc9c6c3c0bdbab7b4b1aeaba8a5a29f9c999693908d8a8784817e7b7875726f6c696663605d5a5754514e4b484542")
+
+	pathBIP44    = []uint32{segwit.PurposeBIP44, segwit.CoinTypeBTC, segwit.Apostrophe, 0, 0}
+	pathBIP49    = []uint32{segwit.PurposeBIP49, segwit.CoinTypeBTC, segwit.Apostrophe, 0, 0}
+	pathBIP84    = []uint32{segwit.PurposeBIP84, segwit.CoinTypeBTC, segwit.Apostrophe, 0, 0}
+	pathChange   = []uint32{segwit.PurposeBIP84, segwit.CoinTypeBTC, segwit.Apostrophe, 1, 0}
+	pathCosigner = []uint32{multisig.PurposeBIP48, segwit.CoinTypeBTC, segwit.Apostrophe, 0x80000002, 0, 0}
+)
+
+// testFixture a transaction spending P2WPKH, P2SH-P2WPKH, P2PKH and 2-of-3 P2WSH outputs
+type testFixture struct {
+	tx       *transaction.UnsignedTransaction
+	prevTx   *wire.MsgTx
+	managers []*segwit.KeyManager
+}
+
+func newTestFixture(t *testing.T) *testFixture {
+	var f testFixture
+	for _, seed := range [][]byte{seedA, seedB, seedC} {
+		km, _ := segwit.NewKeyManager(seed, &chaincfg.MainNetParams)
+		f.managers = append(f.managers, km)
+	}
+
+	publicKey := func(km *segwit.KeyManager, path []uint32) []byte {
+		key, err := km.KeyAt(path)
+		assert.NoError(t, err, "Expected no error: valid path")
+		return key.PublicKey(true)
+	}
+
+	var cosigners []string
+	for _, km := range f.managers {
+		cosigners = append(cosigners, hex.EncodeToString(publicKey(km, pathCosigner)))
+	}
+	script, err := multisig.GenerateScriptAddress(2, 3, cosigners, multisig.ScriptTypeP2WSH, true, &chaincfg.MainNetParams)
+	assert.NoError(t, err, "Expected no error: valid cosigners")
+
+	nested := witnessPubKeyHashScript(publicKey(f.managers[0], pathBIP49))
+	legacy, _ := txscript.NewScriptBuilder().AddOp(txscript.OP_DUP).AddOp(txscript.OP_HASH160).
+		AddData(btcutil.Hash160(publicKey(f.managers[0], pathBIP44))).AddOp(txscript.OP_EQUALVERIFY).AddOp(txscript.OP_CHECKSIG).Script()
+	nestedScript, _ := txscript.NewScriptBuilder().AddOp(txscript.OP_HASH160).AddData(btcutil.Hash160(nested)).AddOp(txscript.OP_EQUAL).Script()
+
+	// previous transaction of the legacy input
+	f.prevTx = wire.NewMsgTx(1)
+	f.prevTx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0), nil, nil))
+	f.prevTx.AddTxOut(wire.NewTxOut(100000, legacy))
+
+	var utxos = []*transaction.UTXO{
+		{TxID: chainhash.Hash{2}.String(), Vout: 0, Value: 100000, ScriptPubKey: witnessPubKeyHashScript(publicKey(f.managers[0], pathBIP84))},
+		{TxID: chainhash.Hash{3}.String(), Vout: 1, Value: 100000, ScriptPubKey: nestedScript},
+		{TxID: f.prevTx.TxHash().String(), Vout: 0, Value: 100000, ScriptPubKey: legacy},
+		{TxID: chainhash.Hash{4}.String(), Vout: 2, Value: 100000, ScriptPubKey: witnessScriptHashScript(script.WitnessScript()), WitnessScript: script.WitnessScript()},
+	}
+
+	f.tx = &transaction.UnsignedTransaction{Tx: wire.NewMsgTx(2), Inputs: utxos}
+	for _, utxo := range utxos {
+		hash, _ := chainhash.NewHashFromStr(utxo.TxID)
+		f.tx.Tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(hash, utxo.Vout), nil, nil))
+	}
+	f.tx.Tx.AddTxOut(wire.NewTxOut(390000, witnessPubKeyHashScript(publicKey(f.managers[1], pathBIP84))))
+	f.tx.Tx.AddTxOut(wire.NewTxOut(9000, witnessPubKeyHashScript(publicKey(f.managers[0], pathChange))))
+
+	return &f
+}
+
+// newTestPacket build the fixture PSBT with every derivation, the cosigner keys of seeds B and C included
+func (f *testFixture) newTestPacket(t *testing.T) *Packet {
+	p, err := New(f.tx)
+	assert.NoError(t, err, "Expected no error: valid transaction")
+
+	assert.NoError(t, p.SetNonWitnessUTXO(2, f.prevTx), "Expected no error: matching previous transaction")
+	assert.NoError(t, p.AddInputDerivation(0, f.managers[0], pathBIP84), "Expected no error: P2WPKH key")
+	assert.NoError(t, p.AddInputDerivation(1, f.managers[0], pathBIP49), "Expected no error: P2SH-P2WPKH key")
+	assert.NoError(t, p.AddInputDerivation(2, f.managers[0], pathBIP44), "Expected no error: P2PKH key")
+	assert.NoError(t, p.AddInputDerivation(3, f.managers[0], pathCosigner), "Expected no error: cosigner key")
+	for _, km := range f.managers[1:] {
+		key, _ := km.KeyAt(pathCosigner)
+		fingerprint, _ := km.MasterFingerprint()
+		assert.NoError(t, p.AddInputCosigner(3, Derivation{PublicKey: key.PublicKey(true), Fingerprint: fingerprint, Path: pathCosigner}), "Expected no error: cosigner key")
+	}
+	assert.NoError(t, p.AddOutputDerivation(1, f.managers[0], pathChange), "Expected no error: change key")
+
+	return p
+}
+
+func TestNew_RedeemScript(t *testing.T) {
+	var f = newTestFixture(t)
+	key, _ := f.managers[0].KeyAt(pathBIP49)
+	var nested = witnessPubKeyHashScript(key.PublicKey(true))
+	f.tx.Inputs[1].RedeemScript = nested
+
+	// the 2-of-3 multisig script as a legacy P2SH redeem script
+	var redeemScript = f.tx.Inputs[3].WitnessScript
+	scriptPubKey, _ := txscript.NewScriptBuilder().AddOp(txscript.OP_HASH160).AddData(btcutil.Hash160(redeemScript)).AddOp(txscript.OP_EQUAL).Script()
+	f.tx.Inputs[3] = &transaction.UTXO{TxID: chainhash.Hash{4}.String(), Vout: 2, Value: 100000, ScriptPubKey: scriptPubKey, RedeemScript: redeemScript}
+
+	p, err := New(f.tx)
+
+	assert.NoError(t, err, "Expected no error: valid transaction")
+	assert.Equal(t, nested, p.Inputs[1].RedeemScript, "Incorrect P2SH-P2WPKH redeem script")
+	assert.NotNil(t, p.Inputs[1].WitnessUTXO, "Expected the P2SH-P2WPKH witness UTXO")
+	assert.Equal(t, redeemScript, p.Inputs[3].RedeemScript, "Incorrect P2SH multisig redeem script")
+	assert.Nil(t, p.Inputs[3].WitnessUTXO, "Expected no witness UTXO for a legacy P2SH input")
+}
+
+func TestPacket_AddInputDerivation(t *testing.T) {
+	var f = newTestFixture(t)
+	var p = f.newTestPacket(t)
+
+	assert.Len(t, p.Inputs[3].Derivations, 3, "Expected every cosigner derivation")
+	assert.Equal(t, "0014"+hex.EncodeToString(btcutil.Hash160(p.Inputs[1].Derivations[0].PublicKey)), hex.EncodeToString(p.Inputs[1].RedeemScript), "Incorrect P2SH-P2WPKH redeem script")
+	assert.Equal(t, "73c5da0a", hex.EncodeToString(p.Inputs[0].Derivations[0].Fingerprint), "Incorrect fingerprint")
+	assert.Len(t, p.Outputs[1].Derivations, 1, "Expected the change derivation")
+	assert.Empty(t, p.Outputs[0].Derivations, "Expected no derivation on the payment")
+
+	// the seed does not own the payment output
+	assert.Equal(t, ErrKeyMismatch, p.AddOutputDerivation(0, f.managers[0], pathBIP84), "Expected error: key of another seed")
+	assert.Equal(t, ErrKeyMismatch, p.AddInputDerivation(0, f.managers[1], pathBIP84), "Expected error: key of another seed")
+	assert.Equal(t, ErrInputIndex, p.AddInputDerivation(4, f.managers[0], pathBIP84), "Expected error: no fifth input")
+}
+
+func TestSign_Multisig(t *testing.T) {
+	var f = newTestFixture(t)
+	var p = f.newTestPacket(t)
+	encoded, err := p.B64Encode()
+	assert.NoError(t, err, "Expected no error: valid PSBT")
+
+	// seed A signs its single key inputs and its cosigner key
+	signed, err := Sign(p, f.managers[0])
+
+	assert.NoError(t, err, "Expected no error: signable inputs")
+	assert.Equal(t, 4, signed, "Incorrect signature count")
+
+	signed, err = Sign(p, f.managers[0])
+
+	assert.NoError(t, err, "Expected no error: already signed")
+	assert.Equal(t, 0, signed, "Expected no signature twice")
+
+	assert.NoError(t, Finalize(p), "Expected no error: finalizable inputs")
+	assert.False(t, p.Complete(), "Expected the multisig input to miss a signature")
+	assert.True(t, p.Inputs[0].Finalized(), "Expected the P2WPKH input finalized")
+	assert.Nil(t, p.Inputs[0].FinalScriptSig, "Expected no scriptSig for a native segwit input")
+	assert.NotNil(t, p.Inputs[2].FinalScriptSig, "Expected the P2PKH scriptSig")
+	assert.False(t, p.Inputs[3].Finalized(), "Expected the multisig input not finalized")
+	assert.Len(t, p.Inputs[3].PartialSigs, 1, "Expected the seed A signature")
+
+	_, err = Extract(p)
+
+	assert.Equal(t, ErrNotFinalized, err, "Expected error: multisig input missing a signature")
+
+	// seed C cosigns its own copy
+	cosigned, err := ParseBase64(encoded)
+	assert.NoError(t, err, "Expected no error: valid PSBT")
+	signed, err = Sign(cosigned, f.managers[2])
+
+	assert.NoError(t, err, "Expected no error: signable input")
+	assert.Equal(t, 1, signed, "Incorrect signature count")
+
+	combined, err := Combine([]*Packet{p, cosigned})
+	assert.NoError(t, err, "Expected no error: same transaction")
+	assert.NoError(t, Finalize(combined), "Expected no error: finalizable inputs")
+	assert.True(t, combined.Complete(), "Expected every input finalized")
+
+	tx, err := Extract(combined)
+	assert.NoError(t, err, "Expected no error: complete PSBT")
+
+	// every input must pass the script engine
+	var sigHashes = txscript.NewTxSigHashes(tx)
+	for i, utxo := range f.tx.Inputs {
+		engine, err := txscript.NewEngine(utxo.ScriptPubKey, tx, i, txscript.StandardVerifyFlags, nil, sigHashes, utxo.Value)
+		assert.NoError(t, err, "Expected no error: valid engine")
+		assert.NoError(t, engine.Execute(), "Expected a valid input %d", i)
+	}
+
+	fee, err := combined.Fee()
+
+	assert.NoError(t, err, "Expected no error: every UTXO known")
+	assert.Equal(t, int64(1000), fee, "Incorrect fee")
+}
+
+func TestSign_MissingNonWitnessUTXO(t *testing.T) {
+	var f = newTestFixture(t)
+	p, _ := New(f.tx)
+	p.Inputs[2].WitnessUTXO = f.prevTx.TxOut[0]
+	assert.NoError(t, p.AddInputDerivation(2, f.managers[0], pathBIP44), "Expected no error: P2PKH key")
+
+	var _, err = Sign(p, f.managers[0])
+
+	assert.Equal(t, ErrMissingNonWitnessUTXO, err, "Expected error: legacy input without its previous transaction")
+}
+
+func TestCombine_TxMismatch(t *testing.T) {
+	var f = newTestFixture(t)
+	var p = f.newTestPacket(t)
+	other, _ := New(f.tx)
+	other.UnsignedTx.TxOut[0].Value--
+
+	var _, err = Combine([]*Packet{p, other})
+
+	assert.Equal(t, ErrTxMismatch, err, "Expected error: different transactions")
+}
@@ -0,0 +1,167 @@
+package segwit
+
+import (
+	"errors"
+	"strings"
+
+	"btcwalletapi/cryto/bech32m"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcutil/base58"
+)
+
+// AddressType kind of output an address pays to
+type AddressType string
+
+const (
+	AddressTypeP2PKH          AddressType = "p2pkh"
+	AddressTypeP2SH           AddressType = "p2sh"
+	AddressTypeP2WPKH         AddressType = "p2wpkh"
+	AddressTypeP2WSH          AddressType = "p2wsh"
+	AddressTypeP2TR           AddressType = "p2tr"
+	AddressTypeWitnessUnknown AddressType = "witness_unknown"
+)
+
+// hashLength bytes of a P2PKH public key hash or a P2SH script hash
+const hashLength = 20
+
+var (
+	ErrInvalidAddress       = errors.New("invalid address")
+	ErrUnknownAddressPrefix = errors.New("address prefix belongs to no supported network")
+)
+
+// AddressInfo a decoded address
+type AddressInfo struct {
+	Address string
+	// Networks every supported network name the address belongs to, test networks share prefixes
+	Networks []string
+	Type     AddressType
+	// WitnessVersion and WitnessProgram of segwit addresses, WitnessVersion is -1 for base58 addresses
+	WitnessVersion int
+	WitnessProgram []byte
+	ScriptPubKey   []byte
+}
+
+// BelongsTo tell whether the address belongs to the network
+func (a *AddressInfo) BelongsTo(network *chaincfg.Params) bool {
+	for _, name := range a.Networks {
+		if net, _ := ParseNetwork(name); net.Net == network.Net {
+			return true
+		}
+	}
+	return false
+}
+
+// DecodeAddress decode and classify a base58 or bech32/bech32m address of any supported network
+func DecodeAddress(address string) (*AddressInfo, error) {
+	address = strings.TrimSpace(address)
+	if address == "" {
+		return nil, ErrInvalidAddress
+	}
+
+	if payload, version, err := base58.CheckDecode(address); err == nil {
+		return decodeBase58Address(address, payload, version)
+	}
+
+	if !hasSegWitPrefix(address) {
+		return nil, ErrInvalidAddress
+	}
+
+	return decodeSegWitAddress(address)
+}
+
+// hasSegWitPrefix tell whether an address starts with the bech32 human readable part of a supported network
+func hasSegWitPrefix(address string) bool {
+	lower := strings.ToLower(address)
+	for _, name := range SupportedNetworks {
+		network, _ := ParseNetwork(name)
+		if strings.HasPrefix(lower, network.Bech32HRPSegwit+"1") {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeBase58Address classify a P2PKH or P2SH address by its version byte
+func decodeBase58Address(address string, payload []byte, version byte) (*AddressInfo, error) {
+	if len(payload) != hashLength {
+		return nil, ErrInvalidAddress
+	}
+
+	var info = &AddressInfo{Address: address, WitnessVersion: -1}
+	for _, name := range SupportedNetworks {
+		network, _ := ParseNetwork(name)
+		switch version {
+		case network.PubKeyHashAddrID:
+			info.Type = AddressTypeP2PKH
+		case network.ScriptHashAddrID:
+			info.Type = AddressTypeP2SH
+		default:
+			continue
+		}
+		info.Networks = append(info.Networks, name)
+	}
+	if len(info.Networks) == 0 {
+		return nil, ErrUnknownAddressPrefix
+	}
+
+	var err error
+	var builder = txscript.NewScriptBuilder()
+	if info.Type == AddressTypeP2PKH {
+		// OP_DUP OP_HASH160 <hash> OP_EQUALVERIFY OP_CHECKSIG
+		builder.AddOp(txscript.OP_DUP).AddOp(txscript.OP_HASH160).AddData(payload).
+			AddOp(txscript.OP_EQUALVERIFY).AddOp(txscript.OP_CHECKSIG)
+	} else {
+		// OP_HASH160 <hash> OP_EQUAL
+		builder.AddOp(txscript.OP_HASH160).AddData(payload).AddOp(txscript.OP_EQUAL)
+	}
+	info.ScriptPubKey, err = builder.Script()
+	if err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// decodeSegWitAddress classify a segwit address by its witness version and program length,
+// a bech32 checksum on version 1+ or a bech32m checksum on version 0 is reported as such
+func decodeSegWitAddress(address string) (*AddressInfo, error) {
+	hrp, version, program, err := bech32m.DecodeSegWitAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	var info = &AddressInfo{Address: address, WitnessVersion: int(version), WitnessProgram: program}
+	for _, name := range SupportedNetworks {
+		network, _ := ParseNetwork(name)
+		if network.Bech32HRPSegwit == hrp {
+			info.Networks = append(info.Networks, name)
+		}
+	}
+	if len(info.Networks) == 0 {
+		return nil, ErrUnknownAddressPrefix
+	}
+
+	switch {
+	case version == 0 && len(program) == 20:
+		info.Type = AddressTypeP2WPKH
+	case version == 0 && len(program) == 32:
+		info.Type = AddressTypeP2WSH
+	case version == 1 && len(program) == 32:
+		info.Type = AddressTypeP2TR
+	default:
+		info.Type = AddressTypeWitnessUnknown
+	}
+
+	// OP_n <program>
+	var versionOp byte = txscript.OP_0
+	if version > 0 {
+		versionOp = txscript.OP_1 + version - 1
+	}
+	info.ScriptPubKey, err = txscript.NewScriptBuilder().AddOp(versionOp).AddData(program).Script()
+	if err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
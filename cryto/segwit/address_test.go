@@ -0,0 +1,68 @@
+package segwit
+
+import (
+	"btcwalletapi/cryto/bech32m"
+	"encoding/hex"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil/bech32"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDecodeAddress(t *testing.T) {
+	var cases = []struct {
+		address      string
+		addressType  AddressType
+		networks     []string
+		scriptPubKey string
+	}{
+		{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", AddressTypeP2PKH, []string{NetworkMainNet}, "76a91477bff20c60e522dfaa3350c39b030a5d004e839a88ac"},
+		{"3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", AddressTypeP2SH, []string{NetworkMainNet}, "a914b472a266d0bd89c13706a4132ccfb16f7c3b9fcb87"},
+		// BIP173/BIP350 test vectors
+		{"BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4", AddressTypeP2WPKH, []string{NetworkMainNet}, "0014751e76e8199196d454941c45d1b3a323f1433bd6"},
+		{"tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7", AddressTypeP2WSH, []string{NetworkTestNet3, NetworkSigNet}, "00201863143c14c5166804bd19203356da136c985678cd4d27a1b8c6329604903262"},
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0", AddressTypeP2TR, []string{NetworkMainNet}, "512079be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"},
+		{"BC1SW50QGDZ25J", AddressTypeWitnessUnknown, []string{NetworkMainNet}, "6002751e"},
+	}
+
+	for _, c := range cases {
+		info, err := DecodeAddress(c.address)
+
+		assert.NoError(t, err, "Expected no error: valid address "+c.address)
+		assert.Equal(t, c.addressType, info.Type, "Incorrect address type "+c.address)
+		assert.Equal(t, c.networks, info.Networks, "Incorrect networks "+c.address)
+		assert.Equal(t, c.scriptPubKey, hex.EncodeToString(info.ScriptPubKey), "Incorrect scriptPubKey "+c.address)
+	}
+
+	info, _ := DecodeAddress("mipcBbFg9gMiCh81Kj8tqqdgoZub1ZJRfn")
+
+	assert.Equal(t, []string{NetworkTestNet3, NetworkSigNet, NetworkRegTest}, info.Networks, "Expected every test network")
+	assert.True(t, info.BelongsTo(&chaincfg.RegressionNetParams), "Expected a regtest address")
+	assert.False(t, info.BelongsTo(&chaincfg.MainNetParams), "Expected no mainnet address")
+}
+
+func TestDecodeAddress_Errors(t *testing.T) {
+	var _, err = DecodeAddress("")
+
+	assert.Equal(t, ErrInvalidAddress, err, "Expected error: empty address")
+
+	_, err = DecodeAddress("1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN3")
+
+	assert.Equal(t, ErrInvalidAddress, err, "Expected error: base58 checksum")
+
+	// BIP350 test vector, bech32m checksum on a version 0 program
+	_, err = DecodeAddress("bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kemeawh")
+
+	assert.Equal(t, bech32m.ErrBech32Expected, err, "Expected error: bech32m instead of bech32")
+
+	program, _ := hex.DecodeString("79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798")
+	converted, _ := bech32.ConvertBits(program, 8, 5, true)
+	taproot, _ := bech32m.Encode("bc", append([]byte{1}, converted...), bech32m.Bech32)
+	_, err = DecodeAddress(taproot)
+
+	assert.Equal(t, bech32m.ErrBech32mExpected, err, "Expected error: bech32 instead of bech32m")
+
+	_, err = DecodeAddress("ltc1qw508d6qejxtdg4y5r3zarvary0c5xw7kgmn4n9")
+
+	assert.Equal(t, ErrInvalidAddress, err, "Expected error: unsupported human readable part")
+}
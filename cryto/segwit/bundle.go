@@ -0,0 +1,62 @@
+package segwit
+
+import (
+	"github.com/btcsuite/btcutil"
+)
+
+// KeyBundle every encoding of a derived key, to match the transactions paying it
+type KeyBundle struct {
+	Path    string
+	Address string
+	// PublicKey compressed serialization of the public key
+	PublicKey []byte
+	// PublicKeyHash and ScriptPubKey of Address, under the key serialization the address commits to
+	PublicKeyHash []byte
+	ScriptPubKey  []byte
+	// P2PKH, P2SHP2WPKH, P2WPKH and P2TR addresses of the key, Address being one of them
+	P2PKH             string
+	P2SHP2WPKH        string
+	P2WPKH            string
+	P2TR              string
+	ParentFingerprint []byte
+	MasterFingerprint []byte
+}
+
+// GetKeyBundle derive the key at m/purpose'/coin_type'/account'/change/index and give its bundle
+func (km *KeyManager) GetKeyBundle(purpose, coinType, account, change, index uint32) (*KeyBundle, error) {
+	key, err := km.GetKey(purpose, coinType, account, change, index)
+	if err != nil {
+		return nil, err
+	}
+	masterFingerprint, err := km.MasterFingerprint()
+	if err != nil {
+		return nil, err
+	}
+
+	address, addressKey, err := key.AddressKey(purpose)
+	if err != nil {
+		return nil, err
+	}
+	p2pkh, p2wpkh, p2shP2wpkh, p2tr, err := generateFromPubKey(addressKey, km.network)
+	if err != nil {
+		return nil, err
+	}
+	info, err := DecodeAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyBundle{
+		Path:              key.path,
+		Address:           address,
+		PublicKey:         key.PublicKey(true),
+		PublicKeyHash:     btcutil.Hash160(addressKey),
+		ScriptPubKey:      info.ScriptPubKey,
+		P2PKH:             p2pkh,
+		P2SHP2WPKH:        p2shP2wpkh,
+		P2WPKH:            p2wpkh,
+		P2TR:              p2tr,
+		ParentFingerprint: key.bip32Key.FingerPrint,
+		MasterFingerprint: masterFingerprint,
+	}, nil
+}
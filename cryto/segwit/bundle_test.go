@@ -0,0 +1,30 @@
+package segwit
+
+import (
+	"encoding/hex"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestKeyManager_GetKeyBundle(t *testing.T) {
+	var km, _ = NewKeyManager(abandonSeed, &chaincfg.MainNetParams)
+
+	// BIP86 test vector
+	bundle, err := km.GetKeyBundle(PurposeBIP86, CoinTypeBTC, Apostrophe, 0, 0)
+
+	assert.NoError(t, err, "Expected no error: valid path")
+	assert.Equal(t, "m/86'/0'/0'/0/0", bundle.Path, "Incorrect path")
+	assert.Equal(t, "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr", bundle.Address, "Incorrect address")
+	assert.Equal(t, bundle.Address, bundle.P2TR, "Expected the address among the key addresses")
+	assert.Equal(t, "03cc8a4bc64d897bddc5fbc2f670f7a8ba0b386779106cf1223c6fc5d7cd6fc115", hex.EncodeToString(bundle.PublicKey), "Incorrect public key")
+	assert.Equal(t, "5120a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c", hex.EncodeToString(bundle.ScriptPubKey), "Incorrect scriptPubKey")
+	assert.Equal(t, "73c5da0a", hex.EncodeToString(bundle.MasterFingerprint), "Incorrect master fingerprint")
+	assert.Len(t, bundle.ParentFingerprint, 4, "Incorrect parent fingerprint")
+
+	bundle, err = km.GetKeyBundle(PurposeBIP84, CoinTypeBTC, Apostrophe, 0, 0)
+
+	assert.NoError(t, err, "Expected no error: valid path")
+	assert.Equal(t, bundle.Address, bundle.P2WPKH, "Expected the address among the key addresses")
+	assert.Equal(t, "0014"+hex.EncodeToString(bundle.PublicKeyHash), hex.EncodeToString(bundle.ScriptPubKey), "Expected the scriptPubKey of the public key hash")
+}
@@ -0,0 +1,173 @@
+package segwit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/tyler-smith/go-bip32"
+)
+
+// maxDepth deepest BIP32 key, the depth is serialized on a single byte
+const maxDepth = 255
+
+var ErrPathTooDeep = fmt.Errorf("derivation path deeper than %d", maxDepth)
+
+// ExtendedKeyInfo a derived extended key with its BIP32 metadata
+type ExtendedKeyInfo struct {
+	Path              string
+	PublicKey         []byte
+	ChainCode         []byte
+	Depth             byte
+	ParentFingerprint []byte
+	ExtendedPublicKey string
+}
+
+// ParsePath parse an absolute (m/...) or relative BIP32 path of any depth to a component slice,
+// hardened components are suffixed with ', h or H
+func ParsePath(path string) ([]uint32, bool, error) {
+	var result []uint32
+
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, false, ErrEmptyPath
+	}
+
+	components := strings.Split(path, "/")
+	relative := strings.TrimSpace(components[0]) != "m"
+	if !relative {
+		components = components[1:]
+	}
+	if len(components) > maxDepth {
+		return nil, false, ErrPathTooDeep
+	}
+
+	for _, component := range components {
+		if strings.TrimSpace(component) == "" {
+			return nil, false, ErrInvalidPath
+		}
+
+		value, err := parseComponent(component)
+		if err != nil {
+			return nil, false, err
+		}
+
+		result = append(result, value)
+	}
+
+	return result, relative, nil
+}
+
+// FormatPath format components to a normalized path, hardened components suffixed with an apostrophe
+func FormatPath(components []uint32, relative bool) string {
+	var parts []string
+	if !relative {
+		parts = append(parts, "m")
+	}
+	for _, component := range components {
+		if component >= Apostrophe {
+			parts = append(parts, fmt.Sprintf("%d'", component-Apostrophe))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d", component))
+		}
+	}
+
+	return strings.Join(parts, "/")
+}
+
+// DeriveKey derive the key at an absolute path of any depth, every intermediate key is cached
+func (km *KeyManager) DeriveKey(components []uint32) (*Key, error) {
+	if len(components) > maxDepth {
+		return nil, ErrPathTooDeep
+	}
+
+	key, err := km.getMasterKey()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range components {
+		path := FormatPath(components[:i+1], false)
+
+		child, ok := km.getKey(path)
+		if !ok {
+			child, err = key.NewChildKey(components[i])
+			if err != nil {
+				return nil, err
+			}
+			km.setKey(path, child)
+		}
+		key = child
+	}
+
+	return &Key{path: FormatPath(components, false), bip32Key: key, network: km.network, uncompressed: km.uncompressed}, nil
+}
+
+// KeyAt derive the key at an absolute path, through GetKey for m/purpose'/coin_type'/account'/change/index paths
+// and DeriveKey for any other
+func (km *KeyManager) KeyAt(components []uint32) (*Key, error) {
+	if len(components) == 5 &&
+		components[0] >= Apostrophe && components[1] >= Apostrophe && components[2] >= Apostrophe &&
+		components[3] < Apostrophe && components[4] < Apostrophe {
+		return km.GetKey(components[0], components[1], components[2], components[3], components[4])
+	}
+
+	return km.DeriveKey(components)
+}
+
+// newExtendedKeyInfo describe a bip32 key, the extended public key is serialized with version
+func newExtendedKeyInfo(path string, key *bip32.Key, version []byte) *ExtendedKeyInfo {
+	public := key.PublicKey()
+	public.Version = version
+
+	return &ExtendedKeyInfo{
+		Path:              path,
+		PublicKey:         public.Key,
+		ChainCode:         public.ChainCode,
+		Depth:             public.Depth,
+		ParentFingerprint: public.FingerPrint,
+		ExtendedPublicKey: public.B58Serialize(),
+	}
+}
+
+// DeriveFromSeed derive the extended key at an absolute path of any depth from a seed,
+// the extended public key is serialized with the network xpub/tpub version
+func DeriveFromSeed(seed []byte, network *chaincfg.Params, components []uint32) (*ExtendedKeyInfo, error) {
+	km, err := NewKeyManager(seed, network)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := km.DeriveKey(components)
+	if err != nil {
+		return nil, err
+	}
+
+	return newExtendedKeyInfo(key.path, key.bip32Key, network.HDPublicKeyID[:]), nil
+}
+
+// DeriveFromExtendedKey derive the extended public key at a non hardened relative path of an extended public key,
+// the derived key keeps the parent version
+func DeriveFromExtendedKey(xpub string, components []uint32) (*ExtendedKeyInfo, error) {
+	key, _, _, err := ParseExtendedPublicKey(xpub)
+	if err != nil {
+		return nil, err
+	}
+	if int(key.Depth)+len(components) > maxDepth {
+		return nil, ErrPathTooDeep
+	}
+
+	version := key.Version
+	for _, component := range components {
+		if component >= Apostrophe {
+			return nil, ErrHardenedPublicDerivation
+		}
+
+		key, err = key.NewChildKey(component)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return newExtendedKeyInfo(FormatPath(components, true), key, version), nil
+}
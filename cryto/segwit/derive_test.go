@@ -0,0 +1,85 @@
+package segwit
+
+import (
+	"encoding/hex"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParsePath(t *testing.T) {
+	var _, _, err = ParsePath("")
+
+	assert.Error(t, err, "Expected error: empty path")
+
+	_, _, err = ParsePath("m/48'//0")
+
+	assert.Error(t, err, "Expected error: empty component")
+
+	d, relative, err := ParsePath("m/48'/0h/0H/2'/0/0")
+
+	assert.NoError(t, err, "Expected no error: valid path")
+	assert.False(t, relative, "Expected an absolute path")
+	assert.Equal(t, []uint32{0x80000030, 0x80000000, 0x80000000, 0x80000002, 0, 0}, d, "Incorrect components")
+	assert.Equal(t, "m/48'/0'/0'/2'/0/0", FormatPath(d, relative), "Incorrect normalized path")
+
+	d, relative, err = ParsePath("1/7")
+
+	assert.NoError(t, err, "Expected no error: valid relative path")
+	assert.True(t, relative, "Expected a relative path")
+	assert.Equal(t, "1/7", FormatPath(d, relative), "Incorrect normalized path")
+
+	d, _, err = ParsePath("m")
+
+	assert.NoError(t, err, "Expected no error: master path")
+	assert.Empty(t, d, "Expected no component")
+}
+
+func TestDeriveFromSeed(t *testing.T) {
+	// BIP32 test vector 1, chain m/0H/1/2H/2/1000000000
+	var seed, _ = hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	var d, _, _ = ParsePath("m/0H/1/2H/2/1000000000")
+	var info, err = DeriveFromSeed(seed, &chaincfg.MainNetParams, d)
+
+	assert.NoError(t, err, "Expected no error: valid path")
+	assert.Equal(t, "xpub6H1LXWLaKsWFhvm6RVpEL9P4KfRZSW7abD2ttkWP3SSQvnyA8FSVqNTEcYFgJS2UaFcxupHiYkro49S8yGasTvXEYBVPamhGW6cFJodrTHy", info.ExtendedPublicKey, "Incorrect extended public key")
+	assert.Equal(t, byte(5), info.Depth, "Incorrect depth")
+	assert.Equal(t, "d880d7d8", hex.EncodeToString(info.ParentFingerprint), "Incorrect parent fingerprint")
+	assert.Equal(t, "m/0'/1/2'/2/1000000000", info.Path, "Incorrect path")
+
+	// an intermediate extended public key derives the same child without the seed
+	d, _, _ = ParsePath("m/0H/1/2H")
+	parent, err := DeriveFromSeed(seed, &chaincfg.MainNetParams, d)
+
+	assert.NoError(t, err, "Expected no error: valid path")
+
+	d, _, _ = ParsePath("2/1000000000")
+	child, err := DeriveFromExtendedKey(parent.ExtendedPublicKey, d)
+
+	assert.NoError(t, err, "Expected no error: valid relative path")
+	assert.Equal(t, info.ExtendedPublicKey, child.ExtendedPublicKey, "Expected the seed based extended key")
+
+	d, _, _ = ParsePath("2'/0")
+	_, err = DeriveFromExtendedKey(parent.ExtendedPublicKey, d)
+
+	assert.Equal(t, ErrHardenedPublicDerivation, err, "Expected error: hardened public derivation")
+}
+
+func TestKeyManager_KeyAt(t *testing.T) {
+	var seed, _ = hex.DecodeString("5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4")
+	var km, _ = NewKeyManager(seed, &chaincfg.MainNetParams)
+
+	// BIP84 first receive key, through GetKey
+	var key, err = km.KeyAt([]uint32{PurposeBIP84, CoinTypeBTC, Apostrophe, 0, 0})
+
+	assert.NoError(t, err, "Expected no error: valid path")
+	assert.Equal(t, "m/84'/0'/0'/0/0", key.Path(), "Incorrect path")
+	assert.Equal(t, "0330d54fd0dd420a6e5f8d3624f5f3482cae350f79d5f0753bf5beef9c2d91af3c", hex.EncodeToString(key.PublicKey(true)), "Incorrect public key")
+
+	// BIP48 cosigner key, through DeriveKey
+	key, err = km.KeyAt([]uint32{0x80000030, CoinTypeBTC, Apostrophe, 0x80000002, 0, 0})
+
+	assert.NoError(t, err, "Expected no error: valid path")
+	assert.Equal(t, "m/48'/0'/0'/2'/0/0", key.Path(), "Incorrect path")
+	assert.Equal(t, key.PublicKey(true), key.PrivateKey().PubKey().SerializeCompressed(), "Private key does not match")
+}
@@ -0,0 +1,272 @@
+package segwit
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil"
+	"github.com/tyler-smith/go-bip32"
+)
+
+var (
+	ErrInvalidExtendedKey    = errors.New("invalid extended key")
+	ErrUnsupportedKeyVersion = errors.New("unsupported extended key version")
+	ErrNotPublicKey          = errors.New("extended key is not a public key")
+	ErrNetworkMismatch       = errors.New("extended key does not belong to the network")
+)
+
+// keyVersion SLIP-132 version bytes of a serialized extended key pair
+type keyVersion struct {
+	public  []byte
+	private []byte
+}
+
+var (
+	// xpub/xprv BIP44 mainnet
+	versionXPub = keyVersion{public: []byte{0x04, 0x88, 0xb2, 0x1e}, private: []byte{0x04, 0x88, 0xad, 0xe4}}
+	// ypub/yprv BIP49 mainnet
+	versionYPub = keyVersion{public: []byte{0x04, 0x9d, 0x7c, 0xb2}, private: []byte{0x04, 0x9d, 0x78, 0x78}}
+	// zpub/zprv BIP84 mainnet
+	versionZPub = keyVersion{public: []byte{0x04, 0xb2, 0x47, 0x46}, private: []byte{0x04, 0xb2, 0x43, 0x0c}}
+	// tpub/tprv BIP44 testnet
+	versionTPub = keyVersion{public: []byte{0x04, 0x35, 0x87, 0xcf}, private: []byte{0x04, 0x35, 0x83, 0x94}}
+	// upub/uprv BIP49 testnet
+	versionUPub = keyVersion{public: []byte{0x04, 0x4a, 0x52, 0x62}, private: []byte{0x04, 0x4a, 0x4e, 0x28}}
+	// vpub/vprv BIP84 testnet
+	versionVPub = keyVersion{public: []byte{0x04, 0x5f, 0x1c, 0xf6}, private: []byte{0x04, 0x5f, 0x18, 0xbc}}
+	// Ypub/Yprv P2SH-P2WSH multisig mainnet
+	versionYPubMultisig = keyVersion{public: []byte{0x02, 0x95, 0xb4, 0x3f}, private: []byte{0x02, 0x95, 0xb0, 0x05}}
+	// Zpub/Zprv P2WSH multisig mainnet
+	versionZPubMultisig = keyVersion{public: []byte{0x02, 0xaa, 0x7e, 0xd3}, private: []byte{0x02, 0xaa, 0x7a, 0x99}}
+	// Upub/Uprv P2SH-P2WSH multisig testnet
+	versionUPubMultisig = keyVersion{public: []byte{0x02, 0x42, 0x89, 0xef}, private: []byte{0x02, 0x42, 0x85, 0xb5}}
+	// Vpub/Vprv P2WSH multisig testnet
+	versionVPubMultisig = keyVersion{public: []byte{0x02, 0x57, 0x54, 0x83}, private: []byte{0x02, 0x57, 0x50, 0x48}}
+)
+
+// knownVersions every supported SLIP-132 version with the purpose and network kind it stands for
+var knownVersions = []struct {
+	version keyVersion
+	purpose Purpose
+	mainNet bool
+}{
+	{versionXPub, PurposeBIP44, true},
+	{versionYPub, PurposeBIP49, true},
+	{versionZPub, PurposeBIP84, true},
+	{versionTPub, PurposeBIP44, false},
+	{versionUPub, PurposeBIP49, false},
+	{versionVPub, PurposeBIP84, false},
+}
+
+// multisigVersions SLIP-132 versions of multisig cosigner keys with the network kind they stand for
+var multisigVersions = []struct {
+	version keyVersion
+	mainNet bool
+}{
+	{versionYPubMultisig, true},
+	{versionZPubMultisig, true},
+	{versionUPubMultisig, false},
+	{versionVPubMultisig, false},
+}
+
+// extendedKeyVersion pick the SLIP-132 version bytes matching a purpose on a network
+func extendedKeyVersion(purpose Purpose, network *chaincfg.Params) (keyVersion, error) {
+	var mainNet = isMainNet(network)
+
+	switch purpose {
+	case PurposeBIP44, PurposeBIP86:
+		if mainNet {
+			return versionXPub, nil
+		}
+		return versionTPub, nil
+	case PurposeBIP49:
+		if mainNet {
+			return versionYPub, nil
+		}
+		return versionUPub, nil
+	case PurposeBIP84:
+		if mainNet {
+			return versionZPub, nil
+		}
+		return versionVPub, nil
+	default:
+		return keyVersion{}, ErrUnsupportedPurpose
+	}
+}
+
+// GetAccountKey derive the account level key m/purpose'/coin_type'/account'
+func (km *KeyManager) GetAccountKey(purpose, coinType, account uint32) (*Key, error) {
+	path := fmt.Sprintf(`m/%d'/%d'/%d'`, purpose-Apostrophe, coinType-Apostrophe, account-Apostrophe)
+
+	key, err := km.getAccountKey(purpose, coinType, account)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Key{path: path, bip32Key: key, network: km.network, uncompressed: km.uncompressed}, nil
+}
+
+// MasterFingerprint give the first 4 bytes of the master public key hash160
+func (km *KeyManager) MasterFingerprint() ([]byte, error) {
+	master, err := km.getMasterKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return btcutil.Hash160(master.PublicKey().Key)[:4], nil
+}
+
+// ExtendedPublicKey serialize the public part of the key with the SLIP-132 version of the purpose
+func (k *Key) ExtendedPublicKey(purpose Purpose) (string, error) {
+	version, err := extendedKeyVersion(purpose, k.network)
+	if err != nil {
+		return "", err
+	}
+
+	public := k.bip32Key.PublicKey()
+	public.Version = version.public
+
+	return public.B58Serialize(), nil
+}
+
+// GetAccountExtendedPublicKey give the account extended public key and the master fingerprint in hex
+func GetAccountExtendedPublicKey(seed []byte, network *chaincfg.Params, purpose, coinType, account uint32) (string, string, error) {
+	km, err := NewKeyManager(seed, network)
+	if err != nil {
+		return "", "", err
+	}
+
+	key, err := km.GetAccountKey(purpose, coinType, account)
+	if err != nil {
+		return "", "", err
+	}
+
+	xpub, err := key.ExtendedPublicKey(purpose)
+	if err != nil {
+		return "", "", err
+	}
+
+	fingerprint, err := km.MasterFingerprint()
+	if err != nil {
+		return "", "", err
+	}
+
+	return xpub, hex.EncodeToString(fingerprint), nil
+}
+
+// ParseExtendedPublicKey decode a SLIP-132 extended public key, giving the purpose its version stands for
+// and whether it belongs to mainnet
+func ParseExtendedPublicKey(xpub string) (*bip32.Key, Purpose, bool, error) {
+	key, err := deserializeExtendedKey(xpub)
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	for _, known := range knownVersions {
+		switch {
+		case bytes.Equal(key.Version, known.version.public):
+			if key.IsPrivate {
+				return nil, 0, false, ErrInvalidExtendedKey
+			}
+			return key, known.purpose, known.mainNet, nil
+		case bytes.Equal(key.Version, known.version.private):
+			return nil, 0, false, ErrNotPublicKey
+		}
+	}
+
+	return nil, 0, false, ErrUnsupportedKeyVersion
+}
+
+// ParseCosignerExtendedPublicKey decode a multisig cosigner extended public key of any single-sig or multisig
+// SLIP-132 version (Ypub/Zpub, Upub/Vpub) and check it against the network, nil selecting the key network
+func ParseCosignerExtendedPublicKey(xpub string, network *chaincfg.Params) (*bip32.Key, *chaincfg.Params, error) {
+	key, _, mainNet, err := ParseExtendedPublicKey(xpub)
+	if err == ErrUnsupportedKeyVersion {
+		key, mainNet, err = parseMultisigExtendedPublicKey(xpub)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	network, err = extendedKeyNetwork(mainNet, network)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return key, network, nil
+}
+
+// parseMultisigExtendedPublicKey decode an extended public key with a multisig SLIP-132 version
+func parseMultisigExtendedPublicKey(xpub string) (*bip32.Key, bool, error) {
+	key, err := deserializeExtendedKey(xpub)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, known := range multisigVersions {
+		switch {
+		case bytes.Equal(key.Version, known.version.public):
+			if key.IsPrivate {
+				return nil, false, ErrInvalidExtendedKey
+			}
+			return key, known.mainNet, nil
+		case bytes.Equal(key.Version, known.version.private):
+			return nil, false, ErrNotPublicKey
+		}
+	}
+
+	return nil, false, ErrUnsupportedKeyVersion
+}
+
+// deserializeExtendedKey decode a base58 extended key and check a public key lies on secp256k1,
+// which go-bip32 does not
+func deserializeExtendedKey(xkey string) (*bip32.Key, error) {
+	key, err := bip32.B58Deserialize(xkey)
+	if err != nil {
+		return nil, ErrInvalidExtendedKey
+	}
+	if !key.IsPrivate {
+		_, err = btcec.ParsePubKey(key.Key, btcec.S256())
+		if err != nil {
+			return nil, ErrInvalidExtendedKey
+		}
+	}
+
+	return key, nil
+}
+
+// extendedKeyNetwork check an extended key network kind against the requested network,
+// test network keys default to testnet3 when no network is requested
+func extendedKeyNetwork(mainNet bool, network *chaincfg.Params) (*chaincfg.Params, error) {
+	if network == nil {
+		if mainNet {
+			return &chaincfg.MainNetParams, nil
+		}
+		return &chaincfg.TestNet3Params, nil
+	}
+
+	if isMainNet(network) != mainNet {
+		return nil, ErrNetworkMismatch
+	}
+
+	return network, nil
+}
+
+// GetMasterFingerprint give the master fingerprint in hex of a seed
+func GetMasterFingerprint(seed []byte) (string, error) {
+	km, err := NewKeyManager(seed, &chaincfg.MainNetParams)
+	if err != nil {
+		return "", err
+	}
+
+	fingerprint, err := km.MasterFingerprint()
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(fingerprint), nil
+}
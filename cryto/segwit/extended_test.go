@@ -0,0 +1,52 @@
+package segwit
+
+import (
+	"encoding/hex"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// seed of the BIP49/BIP84 test mnemonic "abandon abandon ... about"
+var abandonSeed, _ = hex.DecodeString("5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4")
+
+func TestGetAccountExtendedPublicKey(t *testing.T) {
+	var xpub, fingerprint, err = GetAccountExtendedPublicKey(abandonSeed, &chaincfg.MainNetParams, PurposeBIP84, CoinTypeBTC, Apostrophe)
+
+	assert.NoError(t, err, "Expected no error: valid input")
+	assert.Equal(t, "zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs", xpub, "Incorrect zpub")
+	assert.Equal(t, "73c5da0a", fingerprint, "Incorrect master fingerprint")
+
+	xpub, _, err = GetAccountExtendedPublicKey(abandonSeed, &chaincfg.MainNetParams, PurposeBIP49, CoinTypeBTC, Apostrophe)
+
+	assert.NoError(t, err, "Expected no error: valid input")
+	assert.Equal(t, "ypub6Ww3ibxVfGzLrAH1PNcjyAWenMTbbAosGNB6VvmSEgytSER9azLDWCxoJwW7Ke7icmizBMXrzBx9979FfaHxHcrArf3zbeJJJUZPf663zsP", xpub, "Incorrect ypub")
+
+	xpub, _, err = GetAccountExtendedPublicKey(abandonSeed, &chaincfg.MainNetParams, PurposeBIP44, CoinTypeBTC, Apostrophe)
+
+	assert.NoError(t, err, "Expected no error: valid input")
+	assert.Equal(t, "xpub6BosfCnifzxcFwrSzQiqu2DBVTshkCXacvNsWGYJVVhhawA7d4R5WSWGFNbi8Aw6ZRc1brxMyWMzG3DSSSSoekkudhUd9yLb6qx39T9nMdj", xpub, "Incorrect xpub")
+
+	xpub, _, err = GetAccountExtendedPublicKey(abandonSeed, &chaincfg.TestNet3Params, PurposeBIP84, CoinTypeTestnet, Apostrophe)
+
+	assert.NoError(t, err, "Expected no error: valid input")
+	assert.Equal(t, "vpub", xpub[:4], "Expected testnet vpub")
+}
+
+func TestParseAccountPath(t *testing.T) {
+	var _, err = ParseAccountPath("m/84'/0'/0'/0/0", &chaincfg.MainNetParams)
+
+	assert.Error(t, err, "Expected error: not an account path")
+
+	d, err := ParseAccountPath("m/49'/0'/1'", &chaincfg.MainNetParams)
+
+	assert.NoError(t, err, "Expected no error: valid account path")
+	assert.Equal(t, []uint32{0x80000031, 0x80000000, 0x80000001}, d, "Incorrect components")
+}
+
+func TestGetMasterFingerprint(t *testing.T) {
+	var fingerprint, err = GetMasterFingerprint(abandonSeed)
+
+	assert.NoError(t, err, "Expected no error: valid seed")
+	assert.Equal(t, "73c5da0a", fingerprint, "Incorrect master fingerprint")
+}
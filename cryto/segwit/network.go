@@ -0,0 +1,52 @@
+package segwit
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+const (
+	// NetworkMainNet bitcoin main network
+	NetworkMainNet = "mainnet"
+	// NetworkTestNet3 bitcoin test network (version 3)
+	NetworkTestNet3 = "testnet3"
+	// NetworkSigNet bitcoin default signet
+	NetworkSigNet = "signet"
+	// NetworkRegTest bitcoin regression test network
+	NetworkRegTest = "regtest"
+)
+
+var ErrUnsupportedNetwork = errors.New("unsupported network")
+
+// SupportedNetworks every supported network name
+var SupportedNetworks = []string{NetworkMainNet, NetworkTestNet3, NetworkSigNet, NetworkRegTest}
+
+var networks = map[string]*chaincfg.Params{
+	NetworkMainNet:  &chaincfg.MainNetParams,
+	NetworkTestNet3: &chaincfg.TestNet3Params,
+	"testnet":       &chaincfg.TestNet3Params,
+	NetworkSigNet:   &chaincfg.SigNetParams,
+	NetworkRegTest:  &chaincfg.RegressionNetParams,
+}
+
+// ParseNetwork resolve a network name to its chain parameters, an empty name selects mainnet
+func ParseNetwork(name string) (*chaincfg.Params, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return &chaincfg.MainNetParams, nil
+	}
+
+	net, ok := networks[name]
+	if !ok {
+		return nil, ErrUnsupportedNetwork
+	}
+
+	return net, nil
+}
+
+// isMainNet tell whether the chain parameters belong to bitcoin main network
+func isMainNet(net *chaincfg.Params) bool {
+	return net.Net == chaincfg.MainNetParams.Net
+}
@@ -1,6 +1,7 @@
 package segwit
 
 import (
+	"btcwalletapi/cryto/taproot"
 	"errors"
 	"fmt"
 	"github.com/btcsuite/btcd/btcec"
@@ -22,29 +23,46 @@ const (
 	PurposeBIP49 Purpose = 0x80000031
 	// PurposeBIP84 84' BIP84
 	PurposeBIP84 Purpose = 0x80000054
+	// PurposeBIP86 86' BIP86
+	PurposeBIP86 Purpose = 0x80000056
 )
 
 var supportedPurpose = []Purpose{
 	PurposeBIP44,
 	PurposeBIP49,
 	PurposeBIP84,
+	PurposeBIP86,
 }
 
 type CoinType = uint32
 
 const (
+	// CoinTypeBTC 0' bitcoin main network
 	CoinTypeBTC CoinType = 0x80000000
+	// CoinTypeTestnet 1' every bitcoin test network
+	CoinTypeTestnet CoinType = 0x80000001
 )
 
 var supportedCoinTyped = []CoinType{
 	CoinTypeBTC,
 }
 
+var supportedTestnetCoinTyped = []CoinType{
+	CoinTypeTestnet,
+}
+
 const (
 	// Apostrophe 0'
 	Apostrophe uint32 = 0x80000000
 )
 
+const (
+	// ChainReceive external chain, used for receiving addresses
+	ChainReceive uint32 = 0
+	// ChainChange internal chain, used for change addresses
+	ChainChange uint32 = 1
+)
+
 var (
 	ErrEmptyPath           = errors.New("empty derivation path")
 	ErrInvalidPathPrefix   = errors.New("use 'm/' prefix for absolute paths")
@@ -53,31 +71,79 @@ var (
 	ErrComponentOutOfRange = fmt.Errorf("component out of allowed range [0, %d]", math.MaxUint32)
 	ErrUnsupportedCoinType = errors.New("unsupported coinType")
 	ErrUnsupportedPurpose  = errors.New("unsupported purpose")
+	ErrIndexOutOfRange     = errors.New("address index range exceeds the non hardened indexes")
+	ErrUnsupportedChain    = errors.New("unsupported chain, use receive or change")
 )
 
 type Key struct {
 	path     string
 	bip32Key *bip32.Key
+	network  *chaincfg.Params
+	// uncompressed address keys of the legacy derivation, see KeyManager.SetUncompressed
+	uncompressed bool
+}
+
+func (k *Key) encode(compress bool) (wif, address, segwitBech32, segwitNested, taprootBech32m string, err error) {
+	prvKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), k.bip32Key.Key)
+	return generateFromBytes(prvKey, compress, k.network)
+}
+
+// PublicKey serialize the public key of a private extended key
+func (k *Key) PublicKey(compress bool) []byte {
+	_, pubKey := btcec.PrivKeyFromBytes(btcec.S256(), k.bip32Key.Key)
+	if compress {
+		return pubKey.SerializeCompressed()
+	}
+	return pubKey.SerializeUncompressed()
 }
 
-func (k *Key) encode(compress bool) (wif, address, segwitBech32, segwitNested string, err error) {
+// PrivateKey give the secp256k1 private key of a private extended key
+func (k *Key) PrivateKey() *btcec.PrivateKey {
 	prvKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), k.bip32Key.Key)
-	return generateFromBytes(prvKey, compress)
+	return prvKey
+}
+
+// Path give the absolute derivation path of the key
+func (k *Key) Path() string {
+	return k.path
+}
+
+// ExtendedKey give the private BIP32 extended key
+func (k *Key) ExtendedKey() *bip32.Key {
+	return k.bip32Key
+}
+
+// DerivedAddress an address with the path and serialized public key it was derived from
+type DerivedAddress struct {
+	Path      string
+	Address   string
+	PublicKey []byte
 }
 
 type KeyManager struct {
-	seed []byte
-	keys map[string]*bip32.Key
+	seed         []byte
+	network      *chaincfg.Params
+	keys         map[string]*bip32.Key
+	uncompressed bool
 }
 
-func newKeyManager(seed []byte) (*KeyManager, error) {
+// NewKeyManager create a key manager deriving and caching the keys of a seed
+func NewKeyManager(seed []byte, network *chaincfg.Params) (*KeyManager, error) {
 	km := &KeyManager{
-		seed: seed,
-		keys: make(map[string]*bip32.Key, 0),
+		seed:    seed,
+		network: network,
+		keys:    make(map[string]*bip32.Key, 0),
 	}
 	return km, nil
 }
 
+// SetUncompressed derive BIP44, BIP49 and BIP84 addresses from uncompressed public keys like the releases
+// before BIP49/BIP84 compliance did, for wallets relying on those addresses. The P2SH-P2WPKH and P2WPKH
+// addresses of uncompressed keys are non-standard, funds sent to them are not relayed when spent
+func (km *KeyManager) SetUncompressed(uncompressed bool) {
+	km.uncompressed = uncompressed
+}
+
 func (km *KeyManager) getSeed() []byte {
 	return km.seed
 }
@@ -206,7 +272,7 @@ func (km *KeyManager) GetKey(purpose, coinType, account, change, index uint32) (
 
 	key, ok := km.getKey(path)
 	if ok {
-		return &Key{path: path, bip32Key: key}, nil
+		return &Key{path: path, bip32Key: key, network: km.network, uncompressed: km.uncompressed}, nil
 	}
 
 	parent, err := km.getChangeKey(purpose, coinType, account, change)
@@ -221,20 +287,29 @@ func (km *KeyManager) GetKey(purpose, coinType, account, change, index uint32) (
 
 	km.setKey(path, key)
 
-	return &Key{path: path, bip32Key: key}, nil
+	return &Key{path: path, bip32Key: key, network: km.network, uncompressed: km.uncompressed}, nil
 }
 
-func generateFromBytes(prvKey *btcec.PrivateKey, compress bool) (wif, address, segwitBech32, segwitNested string, err error) {
+func generateFromBytes(prvKey *btcec.PrivateKey, compress bool, network *chaincfg.Params) (wif, address, segwitBech32, segwitNested, taprootBech32m string, err error) {
 	// generate the wif(wallet import format) string
-	btcwif, err := btcutil.NewWIF(prvKey, &chaincfg.MainNetParams, compress)
+	btcwif, err := btcutil.NewWIF(prvKey, network, compress)
 	if err != nil {
-		return "", "", "", "", err
+		return "", "", "", "", "", err
 	}
 	wif = btcwif.String()
 
+	address, segwitBech32, segwitNested, taprootBech32m, err = generateFromPubKey(btcwif.SerializePubKey(), network)
+	if err != nil {
+		return "", "", "", "", "", err
+	}
+
+	return wif, address, segwitBech32, segwitNested, taprootBech32m, nil
+}
+
+// generateFromPubKey encode a serialized public key to its p2pkh, p2wpkh, p2sh-p2wpkh and p2tr addresses
+func generateFromPubKey(serializedPubKey []byte, network *chaincfg.Params) (address, segwitBech32, segwitNested, taprootBech32m string, err error) {
 	// generate a normal p2pkh address
-	serializedPubKey := btcwif.SerializePubKey()
-	addressPubKey, err := btcutil.NewAddressPubKey(serializedPubKey, &chaincfg.MainNetParams)
+	addressPubKey, err := btcutil.NewAddressPubKey(serializedPubKey, network)
 	if err != nil {
 		return "", "", "", "", err
 	}
@@ -242,7 +317,7 @@ func generateFromBytes(prvKey *btcec.PrivateKey, compress bool) (wif, address, s
 
 	// generate a normal p2wkh address from the pubkey hash
 	witnessProg := btcutil.Hash160(serializedPubKey)
-	addressWitnessPubKeyHash, err := btcutil.NewAddressWitnessPubKeyHash(witnessProg, &chaincfg.MainNetParams)
+	addressWitnessPubKeyHash, err := btcutil.NewAddressWitnessPubKeyHash(witnessProg, network)
 	if err != nil {
 		return "", "", "", "", err
 	}
@@ -256,17 +331,37 @@ func generateFromBytes(prvKey *btcec.PrivateKey, compress bool) (wif, address, s
 	if err != nil {
 		return "", "", "", "", err
 	}
-	addressScriptHash, err := btcutil.NewAddressScriptHash(serializedScript, &chaincfg.MainNetParams)
+	addressScriptHash, err := btcutil.NewAddressScriptHash(serializedScript, network)
 	if err != nil {
 		return "", "", "", "", err
 	}
 	segwitNested = addressScriptHash.EncodeAddress()
 
-	return wif, address, segwitBech32, segwitNested, nil
+	// generate a BIP86 key path only p2tr address, the x-only
+	// internal key does not depend on the serialization
+	pubKey, err := btcec.ParsePubKey(serializedPubKey, btcec.S256())
+	if err != nil {
+		return "", "", "", "", err
+	}
+	taprootBech32m, err = taproot.Address(pubKey, network)
+	if err != nil {
+		return "", "", "", "", err
+	}
+
+	return address, segwitBech32, segwitNested, taprootBech32m, nil
 }
 
-// ParseDerivationPath parse a string absolute path to a component slice
-func ParseDerivationPath(path string) ([]uint32, error) {
+// ParseDerivationPath parse a string absolute path to a component slice, coin type 1' is only allowed outside mainnet
+func ParseDerivationPath(path string, network *chaincfg.Params) ([]uint32, error) {
+	return parseBIP44Path(path, network, 5)
+}
+
+// ParseAccountPath parse a string absolute account path (m/purpose'/coin_type'/account') to a component slice
+func ParseAccountPath(path string, network *chaincfg.Params) ([]uint32, error) {
+	return parseBIP44Path(path, network, 3)
+}
+
+func parseBIP44Path(path string, network *chaincfg.Params, depth int) ([]uint32, error) {
 	var result []uint32
 
 	// Handle absolute or relative paths
@@ -282,38 +377,24 @@ func ParseDerivationPath(path string) ([]uint32, error) {
 		components = components[1:]
 	}
 	// All remaining components are relative, append one by one
-	if len(components) != 5 {
+	if len(components) != depth {
 		return nil, ErrInvalidPath
 	}
 	for _, component := range components {
-		// Ignore any user added whitespace
-		component = strings.TrimSpace(component)
-		var value uint32
-
-		// Handle hardened paths
-		if strings.HasSuffix(component, "'") {
-			value = 0x80000000
-			component = strings.TrimSpace(strings.TrimSuffix(component, "'"))
-		}
-		// Handle the non hardened component
-		bigval, ok := new(big.Int).SetString(component, 0)
-		if !ok {
-			return nil, ErrInvalidComponent
-		}
-		max := math.MaxUint32 - value
-		if bigval.Sign() < 0 || bigval.Cmp(big.NewInt(int64(max))) > 0 {
-			if value == 0 {
-				return nil, ErrComponentOutOfRange
-			}
-			return nil, ErrComponentOutOfRange
+		value, err := parseComponent(component)
+		if err != nil {
+			return nil, err
 		}
-		value += uint32(bigval.Uint64())
 
 		// Append and repeat
 		result = append(result, value)
 	}
 
-	if !contains(supportedCoinTyped, result[1]) {
+	coinTypes := supportedCoinTyped
+	if !isMainNet(network) {
+		coinTypes = supportedTestnetCoinTyped
+	}
+	if !contains(coinTypes, result[1]) {
 		return nil, ErrUnsupportedCoinType
 	}
 
@@ -324,6 +405,46 @@ func ParseDerivationPath(path string) ([]uint32, error) {
 	return result, nil
 }
 
+// ParseChain resolve a chain name (receive or change) to its path component
+func ParseChain(name string) (uint32, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "receive", "external", "0":
+		return ChainReceive, nil
+	case "change", "internal", "1":
+		return ChainChange, nil
+	default:
+		return 0, ErrUnsupportedChain
+	}
+}
+
+// parseComponent parse a single path component, hardened when suffixed with ', h or H
+func parseComponent(component string) (uint32, error) {
+	// Ignore any user added whitespace
+	component = strings.TrimSpace(component)
+	var value uint32
+
+	// Handle hardened paths
+	for _, marker := range []string{"'", "h", "H"} {
+		if strings.HasSuffix(component, marker) {
+			value = 0x80000000
+			component = strings.TrimSpace(strings.TrimSuffix(component, marker))
+			break
+		}
+	}
+	// Handle the non hardened component
+	bigval, ok := new(big.Int).SetString(component, 0)
+	if !ok {
+		return 0, ErrInvalidComponent
+	}
+	max := math.MaxUint32 - value
+	if bigval.Sign() < 0 || bigval.Cmp(big.NewInt(int64(max))) > 0 {
+		return 0, ErrComponentOutOfRange
+	}
+	value += uint32(bigval.Uint64())
+
+	return value, nil
+}
+
 func contains(s []uint32, e uint32) bool {
 	for _, a := range s {
 		if a == e {
@@ -334,24 +455,57 @@ func contains(s []uint32, e uint32) bool {
 }
 
 // GetAddress generate a Hierarchical Deterministic Segregated Witness bitcoin address
-func GetAddress(seed []byte, purpose, coinType, account, change, index uint32) (string, error) {
+func GetAddress(seed []byte, network *chaincfg.Params, purpose, coinType, account, change, index uint32) (string, error) {
 	var err error
 
-	km, err := newKeyManager(seed)
+	km, err := NewKeyManager(seed, network)
 	if err != nil {
 		return "", err
 	}
 
+	return km.GetAddress(purpose, coinType, account, change, index)
+}
+
+// GetAddress generate the address of the key at m/purpose'/coin_type'/account'/change/index
+func (km *KeyManager) GetAddress(purpose, coinType, account, change, index uint32) (string, error) {
 	key, err := km.GetKey(purpose, coinType, account, change, index)
 	if err != nil {
 		return "", err
 	}
 
-	_, address, segwitBech32, segwitNested, err := key.encode(false)
+	address, _, err := key.AddressKey(purpose)
+
+	return address, err
+}
+
+// AddressKey give the address a purpose stands for and the serialized public key it commits to,
+// compressed as BIP44/49/84 expect unless the key manager is set uncompressed
+func (k *Key) AddressKey(purpose Purpose) (string, []byte, error) {
+	_, address, segwitBech32, segwitNested, taprootBech32m, err := k.encode(!k.uncompressed)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return selectAddress(purpose, address, segwitBech32, segwitNested, taprootBech32m), k.PublicKey(!k.uncompressed), nil
+}
+
+// PublicKeyAddress give the address a purpose stands for of a serialized public key, P2PKH (BIP44),
+// P2SH-P2WPKH (BIP49), P2WPKH (BIP84) or BIP86 P2TR
+func PublicKeyAddress(purpose Purpose, serializedPubKey []byte, network *chaincfg.Params) (string, error) {
+	if !contains(supportedPurpose, purpose) {
+		return "", ErrUnsupportedPurpose
+	}
+
+	address, segwitBech32, segwitNested, taprootBech32m, err := generateFromPubKey(serializedPubKey, network)
 	if err != nil {
 		return "", err
 	}
 
+	return selectAddress(purpose, address, segwitBech32, segwitNested, taprootBech32m), nil
+}
+
+// selectAddress pick the address type a purpose stands for
+func selectAddress(purpose Purpose, address, segwitBech32, segwitNested, taprootBech32m string) string {
 	var result string
 	switch purpose {
 	case PurposeBIP44:
@@ -360,6 +514,48 @@ func GetAddress(seed []byte, purpose, coinType, account, change, index uint32) (
 		result = segwitNested
 	case PurposeBIP84:
 		result = segwitBech32
+	case PurposeBIP86:
+		result = taprootBech32m
+	}
+
+	return result
+}
+
+// GetAddressRange generate count consecutive addresses of an account chain starting at index start,
+// the master to chain keys are derived once and reused for every index
+func GetAddressRange(seed []byte, network *chaincfg.Params, purpose, coinType, account, change, start, count uint32) ([]DerivedAddress, error) {
+	km, err := NewKeyManager(seed, network)
+	if err != nil {
+		return nil, err
+	}
+
+	return km.GetAddressRange(purpose, coinType, account, change, start, count)
+}
+
+// GetAddressRange generate count consecutive addresses of the m/purpose'/coin_type'/account'/change chain
+// starting at index start
+func (km *KeyManager) GetAddressRange(purpose, coinType, account, change, start, count uint32) ([]DerivedAddress, error) {
+	if uint64(start)+uint64(count) > uint64(Apostrophe) {
+		return nil, ErrIndexOutOfRange
+	}
+
+	result := make([]DerivedAddress, 0, count)
+	for index := start; index < start+count; index++ {
+		key, err := km.GetKey(purpose, coinType, account, change, index)
+		if err != nil {
+			return nil, err
+		}
+
+		address, publicKey, err := key.AddressKey(purpose)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, DerivedAddress{
+			Path:      key.path,
+			Address:   address,
+			PublicKey: publicKey,
+		})
 	}
 
 	return result, nil
@@ -1,62 +1,255 @@
 package segwit
 
 import (
+	"encoding/hex"
 	"fmt"
+	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/stretchr/testify/assert"
 	"math"
 	"strconv"
+	"strings"
 	"testing"
 )
 
 func TestGetAddress(t *testing.T) {
 	var seed = []byte{244, 184, 4, 62, 59, 59, 77, 11, 158, 60, 124, 218, 129, 214, 134, 140, 51, 26, 174, 204, 128, 85, 93, 199, 178, 208, 237, 206, 107, 115, 234, 80, 169, 29, 103, 88, 111, 116, 97, 205, 70, 202, 204, 238, 110, 36, 10, 89, 138, 154, 170, 48, 99, 205, 217, 190, 198, 90, 61, 36, 211, 170, 85, 27}
-	var address, err = GetAddress(seed, 0x80000000+84, 0x80000000, 0x80000000, 0, 0)
+	var address, err = GetAddress(seed, &chaincfg.MainNetParams, 0x80000000+84, 0x80000000, 0x80000000, 0, 0)
 
-	var expected = "bc1qjslpmfrrmhu4hwmsd7an9n3hctys6452xln2ek"
+	var expected = "bc1q84kvjqueplk6h80x89j4mjujvq3svfwcz8pavd"
 	assert.NoError(t, err, "Expected no error: valid input")
 	assert.Equal(t, expected, address, "Incorrect address")
+
+	// legacy uncompressed key derivation
+	km, _ := NewKeyManager(seed, &chaincfg.MainNetParams)
+	km.SetUncompressed(true)
+	address, err = km.GetAddress(PurposeBIP84, CoinTypeBTC, Apostrophe, 0, 0)
+
+	expected = "bc1qjslpmfrrmhu4hwmsd7an9n3hctys6452xln2ek"
+	assert.NoError(t, err, "Expected no error: valid input")
+	assert.Equal(t, expected, address, "Incorrect legacy address")
 }
 
 func TestParseDerivationPath(t *testing.T) {
 	var path = ""
-	var _, err = ParseDerivationPath(path)
+	var _, err = ParseDerivationPath(path, &chaincfg.MainNetParams)
 
 	assert.Error(t, err, "Expected error: empty path")
 
 	path = "m/84'/0'/0'/0"
-	_, err = ParseDerivationPath(path)
+	_, err = ParseDerivationPath(path, &chaincfg.MainNetParams)
 
 	assert.Error(t, err, "Expected error: short path")
 
 	path = "m/84'/a'/0'/0/0"
-	_, err = ParseDerivationPath(path)
+	_, err = ParseDerivationPath(path, &chaincfg.MainNetParams)
 
 	assert.Error(t, err, "Expected error: invalid character")
 
 	path = fmt.Sprintf("m/84'/a'/%s'/0/0", strconv.FormatUint(math.MaxUint64, 10))
-	_, err = ParseDerivationPath(path)
+	_, err = ParseDerivationPath(path, &chaincfg.MainNetParams)
 
 	assert.Error(t, err, "Expected error: component out of allowed range")
 
 	path = "m/84'/-1'/0'/0/0"
-	_, err = ParseDerivationPath(path)
+	_, err = ParseDerivationPath(path, &chaincfg.MainNetParams)
 
 	assert.Error(t, err, "Expected error: negative component")
 
 	path = "m/50'/0'/0'/0/0"
-	_, err = ParseDerivationPath(path)
+	_, err = ParseDerivationPath(path, &chaincfg.MainNetParams)
 
 	assert.Error(t, err, "Expected error: unsupported purpose")
 
 	path = "m/50'/2'/0'/0/0"
-	_, err = ParseDerivationPath(path)
+	_, err = ParseDerivationPath(path, &chaincfg.MainNetParams)
 
 	assert.Error(t, err, "Expected error: unsupported coin type")
 
 	path = "m/84'/0'/0'/0/0"
-	d, err := ParseDerivationPath(path)
+	d, err := ParseDerivationPath(path, &chaincfg.MainNetParams)
 	var expected = []uint32{0x80000054, 0x80000000, 0x80000000, 0x0, 0x0}
 
 	assert.NoError(t, err, "Expected no error: valid path")
 	assert.Equal(t, expected, d, "Incorrect components")
 }
+
+func TestGetAddress_Testnet(t *testing.T) {
+	var seed = []byte{244, 184, 4, 62, 59, 59, 77, 11, 158, 60, 124, 218, 129, 214, 134, 140, 51, 26, 174, 204, 128, 85, 93, 199, 178, 208, 237, 206, 107, 115, 234, 80, 169, 29, 103, 88, 111, 116, 97, 205, 70, 202, 204, 238, 110, 36, 10, 89, 138, 154, 170, 48, 99, 205, 217, 190, 198, 90, 61, 36, 211, 170, 85, 27}
+
+	var address, err = GetAddress(seed, &chaincfg.TestNet3Params, PurposeBIP84, CoinTypeTestnet, 0x80000000, 0, 0)
+
+	assert.NoError(t, err, "Expected no error: valid input")
+	assert.True(t, strings.HasPrefix(address, "tb1q"), "Expected testnet native segwit address")
+
+	address, err = GetAddress(seed, &chaincfg.RegressionNetParams, PurposeBIP84, CoinTypeTestnet, 0x80000000, 0, 0)
+
+	assert.NoError(t, err, "Expected no error: valid input")
+	assert.True(t, strings.HasPrefix(address, "bcrt1q"), "Expected regtest native segwit address")
+
+	address, err = GetAddress(seed, &chaincfg.TestNet3Params, PurposeBIP49, CoinTypeTestnet, 0x80000000, 0, 0)
+
+	assert.NoError(t, err, "Expected no error: valid input")
+	assert.True(t, strings.HasPrefix(address, "2"), "Expected testnet nested segwit address")
+
+	address, err = GetAddress(seed, &chaincfg.SigNetParams, PurposeBIP44, CoinTypeTestnet, 0x80000000, 0, 0)
+
+	assert.NoError(t, err, "Expected no error: valid input")
+	assert.Contains(t, []byte("mn"), address[0], "Expected testnet legacy address")
+}
+
+func TestParseDerivationPath_Testnet(t *testing.T) {
+	var path = "m/84'/1'/0'/0/0"
+	var _, err = ParseDerivationPath(path, &chaincfg.MainNetParams)
+
+	assert.Error(t, err, "Expected error: testnet coin type on mainnet")
+
+	d, err := ParseDerivationPath(path, &chaincfg.TestNet3Params)
+	var expected = []uint32{0x80000054, 0x80000001, 0x80000000, 0x0, 0x0}
+
+	assert.NoError(t, err, "Expected no error: valid testnet path")
+	assert.Equal(t, expected, d, "Incorrect components")
+
+	path = "m/84'/0'/0'/0/0"
+	_, err = ParseDerivationPath(path, &chaincfg.RegressionNetParams)
+
+	assert.Error(t, err, "Expected error: mainnet coin type on regtest")
+}
+
+func TestParseNetwork(t *testing.T) {
+	var net, err = ParseNetwork("")
+
+	assert.NoError(t, err, "Expected no error: default network")
+	assert.Equal(t, &chaincfg.MainNetParams, net, "Expected mainnet by default")
+
+	net, err = ParseNetwork("regtest")
+
+	assert.NoError(t, err, "Expected no error: valid network")
+	assert.Equal(t, &chaincfg.RegressionNetParams, net, "Incorrect network")
+
+	_, err = ParseNetwork("litecoin")
+
+	assert.Error(t, err, "Expected error: unsupported network")
+}
+
+func TestGetAddress_BIP86(t *testing.T) {
+	// official BIP86 test vectors, mnemonic "abandon abandon ... about"
+	var vectors = []struct {
+		change, index uint32
+		expected      string
+	}{
+		{0, 0, "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr"},
+		{0, 1, "bc1p4qhjn9zdvkux4e44uhx8tc55attvtyu358kutcqkudyccelu0was9fqzwh"},
+		{1, 0, "bc1p3qkhfews2uk44qtvauqyr2ttdsw7svhkl9nkm9s9c3x4ax5h60wqwruhk7"},
+	}
+
+	for _, v := range vectors {
+		var address, err = GetAddress(abandonSeed, &chaincfg.MainNetParams, PurposeBIP86, CoinTypeBTC, Apostrophe, v.change, v.index)
+
+		assert.NoError(t, err, "Expected no error: valid input")
+		assert.Equal(t, v.expected, address, "Incorrect taproot address")
+	}
+
+	var xpub, _, err = GetAccountExtendedPublicKey(abandonSeed, &chaincfg.MainNetParams, PurposeBIP86, CoinTypeBTC, Apostrophe)
+
+	assert.NoError(t, err, "Expected no error: valid input")
+	assert.Equal(t, "xpub6BgBgsespWvERF3LHQu6CnqdvfEvtMcQjYrcRzx53QJjSxarj2afYWcLteoGVky7D3UKDP9QyrLprQ3VCECoY49yfdDEHGCtMMj92pReUsQ", xpub, "Incorrect account xpub")
+
+	d, err := ParseDerivationPath("m/86'/0'/0'/0/0", &chaincfg.MainNetParams)
+
+	assert.NoError(t, err, "Expected no error: valid taproot path")
+	assert.Equal(t, []uint32{0x80000056, 0x80000000, 0x80000000, 0x0, 0x0}, d, "Incorrect components")
+}
+
+func TestGetAddress_BIP84(t *testing.T) {
+	// official BIP84 test vectors, mnemonic "abandon abandon ... about"
+	var vectors = []struct {
+		change, index uint32
+		publicKey     string
+		expected      string
+	}{
+		{0, 0, "0330d54fd0dd420a6e5f8d3624f5f3482cae350f79d5f0753bf5beef9c2d91af3c", "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu"},
+		{0, 1, "03e775fd51f0dfb8cd865d9ff1cca2a158cf651fe997fdc9fee9c1d3b5e995ea77", "bc1qnjg0jd8228aq7egyzacy8cys3knf9xvrerkf9g"},
+		{1, 0, "03025324888e429ab8e3dbaf1f7802648b9cd01e9b418485c5fa4c1b9b5700e1a6", "bc1q8c6fshw2dlwun7ekn9qwf37cu2rn755upcp6el"},
+	}
+
+	var km, _ = NewKeyManager(abandonSeed, &chaincfg.MainNetParams)
+	for _, v := range vectors {
+		var address, err = GetAddress(abandonSeed, &chaincfg.MainNetParams, PurposeBIP84, CoinTypeBTC, Apostrophe, v.change, v.index)
+
+		assert.NoError(t, err, "Expected no error: valid input")
+		assert.Equal(t, v.expected, address, "Incorrect native segwit address")
+
+		key, _ := km.GetKey(PurposeBIP84, CoinTypeBTC, Apostrophe, v.change, v.index)
+		_, publicKey, _ := key.AddressKey(PurposeBIP84)
+
+		assert.Equal(t, v.publicKey, hex.EncodeToString(publicKey), "Incorrect public key")
+	}
+
+	var xpub, _, err = GetAccountExtendedPublicKey(abandonSeed, &chaincfg.MainNetParams, PurposeBIP84, CoinTypeBTC, Apostrophe)
+
+	assert.NoError(t, err, "Expected no error: valid input")
+	assert.Equal(t, "zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs", xpub, "Incorrect account zpub")
+}
+
+func TestGetAddress_BIP49(t *testing.T) {
+	// official BIP49 test vector, testnet, mnemonic "abandon abandon ... about"
+	var km, _ = NewKeyManager(abandonSeed, &chaincfg.TestNet3Params)
+	var key, err = km.GetKey(PurposeBIP49, CoinTypeTestnet, Apostrophe, 0, 0)
+
+	assert.NoError(t, err, "Expected no error: valid input")
+
+	address, publicKey, err := key.AddressKey(PurposeBIP49)
+
+	assert.NoError(t, err, "Expected no error: valid input")
+	assert.Equal(t, "03a1af804ac108a8a51782198c2d034b28bf90c8803f5a53f76276fa69a4eae77f", hex.EncodeToString(publicKey), "Incorrect public key")
+	assert.Equal(t, "2Mww8dCYPUpKHofjgcXcBCEGmniw9CoaiD2", address, "Incorrect nested segwit address")
+
+	address, err = GetAddress(abandonSeed, &chaincfg.TestNet3Params, PurposeBIP49, CoinTypeTestnet, Apostrophe, 0, 0)
+
+	assert.NoError(t, err, "Expected no error: valid input")
+	assert.Equal(t, "2Mww8dCYPUpKHofjgcXcBCEGmniw9CoaiD2", address, "Incorrect nested segwit address")
+}
+
+func TestGetAddressRange(t *testing.T) {
+	var addresses, err = GetAddressRange(abandonSeed, &chaincfg.MainNetParams, PurposeBIP86, CoinTypeBTC, Apostrophe, 0, 0, 2)
+
+	assert.NoError(t, err, "Expected no error: valid input")
+	assert.Len(t, addresses, 2, "Incorrect number of addresses")
+	assert.Equal(t, "m/86'/0'/0'/0/0", addresses[0].Path, "Incorrect path")
+	assert.Equal(t, "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr", addresses[0].Address, "Incorrect address")
+	assert.Equal(t, "m/86'/0'/0'/0/1", addresses[1].Path, "Incorrect path")
+	assert.Equal(t, "bc1p4qhjn9zdvkux4e44uhx8tc55attvtyu358kutcqkudyccelu0was9fqzwh", addresses[1].Address, "Incorrect address")
+
+	_, err = GetAddressRange(abandonSeed, &chaincfg.MainNetParams, PurposeBIP84, CoinTypeBTC, Apostrophe, 0, Apostrophe-1, 2)
+
+	assert.Equal(t, ErrIndexOutOfRange, err, "Expected error: range reaches hardened indexes")
+}
+
+func TestPublicKeyAddress(t *testing.T) {
+	// m/84'/0'/0'/0/0 of the abandon mnemonic
+	var publicKey, _ = hex.DecodeString("0330d54fd0dd420a6e5f8d3624f5f3482cae350f79d5f0753bf5beef9c2d91af3c")
+	var address, err = PublicKeyAddress(PurposeBIP84, publicKey, &chaincfg.MainNetParams)
+
+	assert.NoError(t, err, "Expected no error: valid input")
+	assert.Equal(t, "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu", address, "Incorrect address")
+
+	_, err = PublicKeyAddress(Apostrophe+45, publicKey, &chaincfg.MainNetParams)
+
+	assert.Equal(t, ErrUnsupportedPurpose, err, "Expected error: unsupported purpose")
+}
+
+func TestParseChain(t *testing.T) {
+	var chain, err = ParseChain("receive")
+
+	assert.NoError(t, err, "Expected no error: valid chain")
+	assert.Equal(t, ChainReceive, chain, "Incorrect chain")
+
+	chain, err = ParseChain("change")
+
+	assert.NoError(t, err, "Expected no error: valid chain")
+	assert.Equal(t, ChainChange, chain, "Incorrect chain")
+
+	_, err = ParseChain("savings")
+
+	assert.Error(t, err, "Expected error: unsupported chain")
+}
@@ -0,0 +1,144 @@
+package segwit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+var (
+	ErrHardenedPublicDerivation = errors.New("hardened derivation is not possible from a public key")
+	ErrPurposeMismatch          = errors.New("purpose does not match the extended key version")
+)
+
+// ParseRelativePath parse a string relative path (change/index) of an account extended public key
+func ParseRelativePath(path string) ([]uint32, error) {
+	var result []uint32
+
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, ErrEmptyPath
+	}
+
+	components := strings.Split(path, "/")
+	if len(components) != 2 {
+		return nil, ErrInvalidPath
+	}
+	for _, component := range components {
+		value, err := parseComponent(component)
+		if err != nil {
+			return nil, err
+		}
+		if value >= Apostrophe {
+			return nil, ErrHardenedPublicDerivation
+		}
+
+		result = append(result, value)
+	}
+
+	return result, nil
+}
+
+// GetWatchOnlyAddress generate the change/index address of an account extended public key,
+// the address type follows the key version unless a purpose is given, see ParsePurpose,
+// a nil network is inferred from the key version, uncompressed giving the legacy address,
+// see KeyManager.SetUncompressed
+func GetWatchOnlyAddress(xpub string, network *chaincfg.Params, purpose Purpose, change, index uint32, uncompressed bool) (string, error) {
+	addresses, err := GetWatchOnlyAddressRange(xpub, network, purpose, change, index, 1, uncompressed)
+	if err != nil {
+		return "", err
+	}
+
+	return addresses[0].Address, nil
+}
+
+// GetWatchOnlyAddressRange generate count consecutive addresses of an account extended public key chain
+// starting at index start, the chain key is derived once and reused for every index
+func GetWatchOnlyAddressRange(xpub string, network *chaincfg.Params, purpose Purpose, change, start, count uint32, uncompressed bool) ([]DerivedAddress, error) {
+	account, keyPurpose, mainNet, err := ParseExtendedPublicKey(xpub)
+	if err != nil {
+		return nil, err
+	}
+
+	purpose, err = watchOnlyPurpose(keyPurpose, purpose)
+	if err != nil {
+		return nil, err
+	}
+
+	network, err = extendedKeyNetwork(mainNet, network)
+	if err != nil {
+		return nil, err
+	}
+
+	if change >= Apostrophe {
+		return nil, ErrHardenedPublicDerivation
+	}
+	if uint64(start)+uint64(count) > uint64(Apostrophe) {
+		return nil, ErrIndexOutOfRange
+	}
+
+	changeKey, err := account.NewChildKey(change)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]DerivedAddress, 0, count)
+	for index := start; index < start+count; index++ {
+		key, err := changeKey.NewChildKey(index)
+		if err != nil {
+			return nil, err
+		}
+
+		// serialize like the seed based derivation does, see Key.AddressKey
+		pubKey, err := btcec.ParsePubKey(key.Key, btcec.S256())
+		if err != nil {
+			return nil, err
+		}
+		serializedPubKey := pubKey.SerializeCompressed()
+		if uncompressed {
+			serializedPubKey = pubKey.SerializeUncompressed()
+		}
+
+		address, segwitBech32, segwitNested, taprootBech32m, err := generateFromPubKey(serializedPubKey, network)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, DerivedAddress{
+			Path:      fmt.Sprintf("%d/%d", change, index),
+			Address:   selectAddress(purpose, address, segwitBech32, segwitNested, taprootBech32m),
+			PublicKey: serializedPubKey,
+		})
+	}
+
+	return result, nil
+}
+
+// ParsePurpose resolve a purpose number (44, 49, 84 or 86) to its hardened path component,
+// zero giving no purpose
+func ParsePurpose(number uint32) (Purpose, error) {
+	if number == 0 {
+		return 0, nil
+	}
+	if number >= Apostrophe || !contains(supportedPurpose, number+Apostrophe) {
+		return 0, ErrUnsupportedPurpose
+	}
+
+	return number + Apostrophe, nil
+}
+
+// watchOnlyPurpose resolve the purpose of an account extended public key, BIP86 accounts sharing
+// the xpub/tpub version of BIP44 ones need it requested
+func watchOnlyPurpose(keyPurpose, requested Purpose) (Purpose, error) {
+	switch {
+	case requested == 0, requested == keyPurpose:
+		return keyPurpose, nil
+	case requested == PurposeBIP86 && keyPurpose == PurposeBIP44:
+		return PurposeBIP86, nil
+	default:
+		return 0, ErrPurposeMismatch
+	}
+}
@@ -0,0 +1,143 @@
+package segwit
+
+import (
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/tyler-smith/go-bip32"
+	"testing"
+)
+
+func TestGetWatchOnlyAddress(t *testing.T) {
+	for _, purpose := range []Purpose{PurposeBIP44, PurposeBIP49, PurposeBIP84} {
+		var xpub, _, err = GetAccountExtendedPublicKey(abandonSeed, &chaincfg.MainNetParams, purpose, CoinTypeBTC, Apostrophe)
+
+		assert.NoError(t, err, "Expected no error: valid input")
+
+		expected, err := GetAddress(abandonSeed, &chaincfg.MainNetParams, purpose, CoinTypeBTC, Apostrophe, 1, 7)
+
+		assert.NoError(t, err, "Expected no error: valid input")
+
+		address, err := GetWatchOnlyAddress(xpub, nil, 0, 1, 7, false)
+
+		assert.NoError(t, err, "Expected no error: valid extended public key")
+		assert.Equal(t, expected, address, "Expected the seed based address")
+
+		// legacy uncompressed key derivation
+		km, _ := NewKeyManager(abandonSeed, &chaincfg.MainNetParams)
+		km.SetUncompressed(true)
+		expected, _ = km.GetAddress(purpose, CoinTypeBTC, Apostrophe, 1, 7)
+		address, err = GetWatchOnlyAddress(xpub, nil, 0, 1, 7, true)
+
+		assert.NoError(t, err, "Expected no error: valid extended public key")
+		assert.Equal(t, expected, address, "Expected the legacy seed based address")
+	}
+
+	var vpub, _, err = GetAccountExtendedPublicKey(abandonSeed, &chaincfg.RegressionNetParams, PurposeBIP84, CoinTypeTestnet, Apostrophe)
+
+	assert.NoError(t, err, "Expected no error: valid input")
+
+	expected, err := GetAddress(abandonSeed, &chaincfg.RegressionNetParams, PurposeBIP84, CoinTypeTestnet, Apostrophe, 0, 0)
+
+	assert.NoError(t, err, "Expected no error: valid input")
+
+	address, err := GetWatchOnlyAddress(vpub, &chaincfg.RegressionNetParams, 0, 0, 0, false)
+
+	assert.NoError(t, err, "Expected no error: valid extended public key")
+	assert.Equal(t, expected, address, "Expected the seed based address")
+
+	_, err = GetWatchOnlyAddress(vpub, &chaincfg.MainNetParams, 0, 0, 0, false)
+
+	assert.Equal(t, ErrNetworkMismatch, err, "Expected error: test network key on mainnet")
+
+	_, err = GetWatchOnlyAddress(vpub, nil, 0, Apostrophe, 0, false)
+
+	assert.Equal(t, ErrHardenedPublicDerivation, err, "Expected error: hardened derivation")
+
+	_, err = GetWatchOnlyAddress("zpub-invalid", nil, 0, 0, 0, false)
+
+	assert.Error(t, err, "Expected error: invalid extended key")
+
+	// valid checksum, key off secp256k1
+	offCurve, _ := bip32.B58Deserialize(vpub)
+	offCurve.Key = append([]byte{0x02}, make([]byte, 32)...)
+	_, err = GetWatchOnlyAddress(offCurve.B58Serialize(), nil, 0, 0, 0, false)
+
+	assert.Equal(t, ErrInvalidExtendedKey, err, "Expected error: public key off the curve")
+}
+
+func TestGetWatchOnlyAddress_BIP86(t *testing.T) {
+	// BIP86 accounts share the plain xpub version of BIP44 ones
+	var xpub, _, err = GetAccountExtendedPublicKey(abandonSeed, &chaincfg.MainNetParams, PurposeBIP86, CoinTypeBTC, Apostrophe)
+
+	assert.NoError(t, err, "Expected no error: valid input")
+
+	address, err := GetWatchOnlyAddress(xpub, nil, PurposeBIP86, 0, 0, false)
+
+	assert.NoError(t, err, "Expected no error: valid extended public key")
+	assert.Equal(t, "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr", address, "Incorrect BIP86 address")
+
+	address, err = GetWatchOnlyAddress(xpub, nil, 0, 0, 0, false)
+
+	assert.NoError(t, err, "Expected no error: valid extended public key")
+	assert.Equal(t, "1", address[:1], "Expected the xpub version to give a P2PKH address")
+
+	zpub, _, _ := GetAccountExtendedPublicKey(abandonSeed, &chaincfg.MainNetParams, PurposeBIP84, CoinTypeBTC, Apostrophe)
+	_, err = GetWatchOnlyAddress(zpub, nil, PurposeBIP86, 0, 0, false)
+
+	assert.Equal(t, ErrPurposeMismatch, err, "Expected error: zpub given as a BIP86 account")
+}
+
+func TestParsePurpose(t *testing.T) {
+	var purpose, err = ParsePurpose(86)
+
+	assert.NoError(t, err, "Expected no error: supported purpose")
+	assert.Equal(t, PurposeBIP86, purpose, "Incorrect purpose")
+
+	purpose, err = ParsePurpose(0)
+
+	assert.NoError(t, err, "Expected no error: no purpose")
+	assert.Equal(t, Purpose(0), purpose, "Expected no purpose")
+
+	_, err = ParsePurpose(45)
+
+	assert.Equal(t, ErrUnsupportedPurpose, err, "Expected error: unsupported purpose")
+}
+
+func TestParseRelativePath(t *testing.T) {
+	var _, err = ParseRelativePath("")
+
+	assert.Error(t, err, "Expected error: empty path")
+
+	_, err = ParseRelativePath("0/1/2")
+
+	assert.Error(t, err, "Expected error: too many components")
+
+	_, err = ParseRelativePath("0/1'")
+
+	assert.Error(t, err, "Expected error: hardened component")
+
+	d, err := ParseRelativePath("1/25")
+
+	assert.NoError(t, err, "Expected no error: valid relative path")
+	assert.Equal(t, []uint32{1, 25}, d, "Incorrect components")
+}
+
+func TestGetWatchOnlyAddressRange(t *testing.T) {
+	var xpub, _, err = GetAccountExtendedPublicKey(abandonSeed, &chaincfg.MainNetParams, PurposeBIP84, CoinTypeBTC, Apostrophe)
+
+	assert.NoError(t, err, "Expected no error: valid input")
+
+	expected, err := GetAddressRange(abandonSeed, &chaincfg.MainNetParams, PurposeBIP84, CoinTypeBTC, Apostrophe, 1, 10, 5)
+
+	assert.NoError(t, err, "Expected no error: valid input")
+
+	addresses, err := GetWatchOnlyAddressRange(xpub, nil, 0, 1, 10, 5, false)
+
+	assert.NoError(t, err, "Expected no error: valid extended public key")
+	assert.Len(t, addresses, 5, "Incorrect number of addresses")
+	for i := range addresses {
+		assert.Equal(t, expected[i].Address, addresses[i].Address, "Expected the seed based address")
+		assert.Equal(t, expected[i].PublicKey, addresses[i].PublicKey, "Expected the seed based public key")
+	}
+	assert.Equal(t, "1/10", addresses[0].Path, "Incorrect relative path")
+}
@@ -0,0 +1,91 @@
+package segwit
+
+import (
+	"errors"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil"
+)
+
+var ErrWIFNetwork = errors.New("WIF private key does not belong to the network")
+
+// ExportedKey the WIF and serialized private and public keys of a derived key, with the address
+// its purpose stands for under the public key serialization
+type ExportedKey struct {
+	Path       string
+	WIF        string
+	PrivateKey []byte
+	PublicKey  []byte
+	Compressed bool
+	Address    string
+}
+
+// ImportedKey the addresses a WIF private key controls, segwit v0 addresses are left empty
+// for uncompressed keys which segwit v0 does not relay
+type ImportedKey struct {
+	Network    *chaincfg.Params
+	PublicKey  []byte
+	Compressed bool
+	P2PKH      string
+	P2SHP2WPKH string
+	P2WPKH     string
+	P2TR       string
+}
+
+// Export give the WIF and serialized keys of a key, compress selecting the public key serialization
+// the WIF and the address commit to
+func (k *Key) Export(purpose Purpose, compress bool) (*ExportedKey, error) {
+	wif, address, segwitBech32, segwitNested, taprootBech32m, err := k.encode(compress)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExportedKey{
+		Path:       k.path,
+		WIF:        wif,
+		PrivateKey: k.PrivateKey().Serialize(),
+		PublicKey:  k.PublicKey(compress),
+		Compressed: compress,
+		Address:    selectAddress(purpose, address, segwitBech32, segwitNested, taprootBech32m),
+	}, nil
+}
+
+// ImportWIF decode a WIF private key and give the addresses it controls, honoring its compressed flag,
+// a nil network is inferred from the WIF version, testnet3 for test networks
+func ImportWIF(wif string, network *chaincfg.Params) (*ImportedKey, error) {
+	decoded, err := btcutil.DecodeWIF(wif)
+	if err != nil {
+		return nil, err
+	}
+
+	if network == nil {
+		network = &chaincfg.TestNet3Params
+		if decoded.IsForNet(&chaincfg.MainNetParams) {
+			network = &chaincfg.MainNetParams
+		}
+	}
+	if !decoded.IsForNet(network) {
+		return nil, ErrWIFNetwork
+	}
+
+	// serialize the public key as the WIF flag tells
+	_, address, segwitBech32, segwitNested, taprootBech32m, err := generateFromBytes(decoded.PrivKey, decoded.CompressPubKey, network)
+	if err != nil {
+		return nil, err
+	}
+
+	var result = &ImportedKey{
+		Network:    network,
+		PublicKey:  decoded.SerializePubKey(),
+		Compressed: decoded.CompressPubKey,
+		P2PKH:      address,
+		P2TR:       taprootBech32m,
+	}
+	if len(result.PublicKey) == btcec.PubKeyBytesLenCompressed {
+		result.P2SHP2WPKH = segwitNested
+		result.P2WPKH = segwitBech32
+	}
+
+	return result, nil
+}
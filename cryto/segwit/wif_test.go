@@ -0,0 +1,57 @@
+package segwit
+
+import (
+	"encoding/hex"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestKey_Export(t *testing.T) {
+	var km, _ = NewKeyManager(abandonSeed, &chaincfg.MainNetParams)
+	var key, _ = km.GetKey(PurposeBIP84, CoinTypeBTC, Apostrophe, 0, 0)
+
+	// BIP84 test vector
+	exported, err := key.Export(PurposeBIP84, true)
+
+	assert.NoError(t, err, "Expected no error: valid key")
+	assert.Equal(t, "m/84'/0'/0'/0/0", exported.Path, "Incorrect path")
+	assert.Equal(t, "KyZpNDKnfs94vbrwhJneDi77V6jF64PWPF8x5cdJb8ifgg2DUc9d", exported.WIF, "Incorrect WIF")
+	assert.Equal(t, "0330d54fd0dd420a6e5f8d3624f5f3482cae350f79d5f0753bf5beef9c2d91af3c", hex.EncodeToString(exported.PublicKey), "Incorrect public key")
+	assert.Len(t, exported.PrivateKey, 32, "Incorrect private key")
+	assert.Equal(t, "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu", exported.Address, "Incorrect address")
+
+	exported, _ = key.Export(PurposeBIP84, false)
+
+	assert.Equal(t, "5", exported.WIF[:1], "Expected an uncompressed WIF")
+	assert.Len(t, exported.PublicKey, 65, "Expected an uncompressed public key")
+}
+
+func TestImportWIF(t *testing.T) {
+	// BIP322 test vector key
+	var imported, err = ImportWIF("L3VFeEujGtevx9w18HD1fhRbCH67Az2dpCymeRE1SoPK6XQtaN2k", nil)
+
+	assert.NoError(t, err, "Expected no error: valid WIF")
+	assert.Equal(t, &chaincfg.MainNetParams, imported.Network, "Expected the WIF network")
+	assert.True(t, imported.Compressed, "Expected a compressed key")
+	assert.Equal(t, "bc1q9vza2e8x573nczrlzms0wvx3gsqjx7vavgkx0l", imported.P2WPKH, "Incorrect P2WPKH address")
+	assert.Equal(t, "bc1ppv609nr0vr25u07u95waq5lucwfm6tde4nydujnu8npg4q75mr5sxq8lt3", imported.P2TR, "Incorrect P2TR address")
+	assert.Equal(t, "3", imported.P2SHP2WPKH[:1], "Incorrect P2SH-P2WPKH address")
+	assert.Equal(t, "1", imported.P2PKH[:1], "Incorrect P2PKH address")
+
+	// private key 1, uncompressed
+	imported, err = ImportWIF("5HpHagT65TZzG1PH3CSu63k8DbpvD8s5ip4nEB3kEsreAnchuDf", nil)
+
+	assert.NoError(t, err, "Expected no error: valid WIF")
+	assert.False(t, imported.Compressed, "Expected an uncompressed key")
+	assert.Equal(t, "1EHNa6Q4Jz2uvNExL497mE43ikXhwF6kZm", imported.P2PKH, "Incorrect P2PKH address")
+	assert.Empty(t, imported.P2WPKH, "Expected no P2WPKH address for an uncompressed key")
+
+	_, err = ImportWIF("L3VFeEujGtevx9w18HD1fhRbCH67Az2dpCymeRE1SoPK6XQtaN2k", &chaincfg.TestNet3Params)
+
+	assert.Equal(t, ErrWIFNetwork, err, "Expected error: mainnet WIF on testnet")
+
+	_, err = ImportWIF("L3VFeEujGtevx9w18HD1fhRbCH67Az2dpCymeRE1SoPK6XQtaN2j", nil)
+
+	assert.Error(t, err, "Expected error: invalid checksum")
+}
@@ -0,0 +1,153 @@
+package taproot
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+)
+
+var (
+	ErrInvalidPrivateKey = errors.New("private key out of range")
+	ErrInvalidNonce      = errors.New("schnorr nonce is zero")
+	ErrInvalidSignature  = errors.New("invalid schnorr signature")
+)
+
+// bytes32 serialize a scalar or a coordinate on 32 big endian bytes
+func bytes32(n *big.Int) []byte {
+	var result [32]byte
+	b := n.Bytes()
+	copy(result[32-len(b):], b)
+	return result[:]
+}
+
+// evenScalar negate a private scalar whose public point has an odd y, BIP340 keys being x-only
+func evenScalar(d *big.Int, pubKey *btcec.PublicKey) *big.Int {
+	if hasEvenY(pubKey) {
+		return new(big.Int).Set(d)
+	}
+	return new(big.Int).Sub(btcec.S256().N, d)
+}
+
+// TweakPrivateKey tweak a private key to the secret of the BIP341 output key TweakPublicKey gives,
+// an empty merkle root commits to key path spending only
+func TweakPrivateKey(privateKey *btcec.PrivateKey, merkleRoot []byte) (*btcec.PrivateKey, error) {
+	curve := btcec.S256()
+	if privateKey.D.Sign() <= 0 || privateKey.D.Cmp(curve.N) >= 0 {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	internalKey := privateKey.PubKey()
+	t := new(big.Int).SetBytes(TaggedHash("TapTweak", XOnly(internalKey), merkleRoot))
+	if t.Cmp(curve.N) >= 0 {
+		return nil, ErrInvalidTweak
+	}
+
+	d := evenScalar(privateKey.D, internalKey)
+	d.Add(d, t).Mod(d, curve.N)
+	if d.Sign() == 0 {
+		return nil, ErrInvalidTweak
+	}
+
+	tweaked, _ := btcec.PrivKeyFromBytes(curve, bytes32(d))
+	return tweaked, nil
+}
+
+// Sign give the 64 bytes BIP340 schnorr signature of a 32 bytes message, aux being 32 bytes of auxiliary randomness
+func Sign(privateKey *btcec.PrivateKey, msg, aux []byte) ([]byte, error) {
+	curve := btcec.S256()
+	if privateKey.D.Sign() <= 0 || privateKey.D.Cmp(curve.N) >= 0 {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	pubKey := privateKey.PubKey()
+	d := evenScalar(privateKey.D, pubKey)
+
+	// t = bytes(d) xor hashBIP0340/aux(a)
+	t := bytes32(d)
+	auxHash := TaggedHash("BIP0340/aux", aux)
+	for i := range t {
+		t[i] ^= auxHash[i]
+	}
+
+	k := new(big.Int).SetBytes(TaggedHash("BIP0340/nonce", t, XOnly(pubKey), msg))
+	k.Mod(k, curve.N)
+	if k.Sign() == 0 {
+		return nil, ErrInvalidNonce
+	}
+	rx, ry := curve.ScalarBaseMult(bytes32(k))
+	r := &btcec.PublicKey{Curve: curve, X: rx, Y: ry}
+	k = evenScalar(k, r)
+
+	e := challenge(XOnly(r), XOnly(pubKey), msg)
+	s := new(big.Int).Mul(e, d)
+	s.Add(s, k).Mod(s, curve.N)
+
+	signature := append(XOnly(r), bytes32(s)...)
+	if !Verify(XOnly(pubKey), msg, signature) {
+		return nil, ErrInvalidSignature
+	}
+
+	return signature, nil
+}
+
+// Verify check a BIP340 schnorr signature of a message against an x-only public key
+func Verify(publicKey, msg, signature []byte) bool {
+	curve := btcec.S256()
+	if len(publicKey) != 32 || len(signature) != 64 {
+		return false
+	}
+
+	px, py, ok := liftX(publicKey)
+	if !ok {
+		return false
+	}
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:])
+	if r.Cmp(curve.P) >= 0 || s.Cmp(curve.N) >= 0 {
+		return false
+	}
+
+	// R = sG - eP
+	e := challenge(signature[:32], publicKey, msg)
+	e.Sub(curve.N, e)
+	sx, sy := curve.ScalarBaseMult(bytes32(s))
+	ex, ey := curve.ScalarMult(px, py, bytes32(e))
+	rx, ry := curve.Add(sx, sy, ex, ey)
+
+	if rx.Sign() == 0 && ry.Sign() == 0 {
+		return false
+	}
+	return ry.Bit(0) == 0 && bytes.Equal(bytes32(rx), signature[:32])
+}
+
+// challenge e = int(hashBIP0340/challenge(bytes(R) || bytes(P) || m)) mod n
+func challenge(r, publicKey, msg []byte) *big.Int {
+	e := new(big.Int).SetBytes(TaggedHash("BIP0340/challenge", r, publicKey, msg))
+	return e.Mod(e, btcec.S256().N)
+}
+
+// liftX give the even y point of an x coordinate, false when x is not on the curve
+func liftX(x []byte) (*big.Int, *big.Int, bool) {
+	curve := btcec.S256()
+	px := new(big.Int).SetBytes(x)
+	if px.Cmp(curve.P) >= 0 {
+		return nil, nil, false
+	}
+
+	// y^2 = x^3 + 7, y = (y^2)^((p+1)/4)
+	ySquared := new(big.Int).Exp(px, big.NewInt(3), curve.P)
+	ySquared.Add(ySquared, curve.B).Mod(ySquared, curve.P)
+	exponent := new(big.Int).Add(curve.P, big.NewInt(1))
+	exponent.Rsh(exponent, 2)
+	py := new(big.Int).Exp(ySquared, exponent, curve.P)
+	if new(big.Int).Exp(py, big.NewInt(2), curve.P).Cmp(ySquared) != 0 {
+		return nil, nil, false
+	}
+	if py.Bit(0) == 1 {
+		py.Sub(curve.P, py)
+	}
+
+	return px, py, true
+}
@@ -0,0 +1,84 @@
+package taproot
+
+import (
+	"encoding/hex"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSign(t *testing.T) {
+	// BIP340 test vectors 0 and 1
+	var vectors = []struct {
+		secretKey string
+		publicKey string
+		aux       string
+		msg       string
+		signature string
+	}{
+		{
+			"0000000000000000000000000000000000000000000000000000000000000003",
+			"f9308a019258c31049344f85f89d5229b531c845836f99b08601f113bce036f9",
+			"0000000000000000000000000000000000000000000000000000000000000000",
+			"0000000000000000000000000000000000000000000000000000000000000000",
+			"e907831f80848d1069a5371b402410364bdf1c5f8307b0084c55f1ce2dca821525f66a4a85ea8b71e482a74f382d2ce5ebeee8fdb2172f477df4900d310536c0",
+		},
+		{
+			"b7e151628aed2a6abf7158809cf4f3c762e7160f38b4da56a784d9045190cfef",
+			"dff1d77f2a671c5f36183726db2341be58feae1da2deced843240f7b502ba659",
+			"0000000000000000000000000000000000000000000000000000000000000001",
+			"243f6a8885a308d313198a2e03707344a4093822299f31d0082efa98ec4e6c89",
+			"6896bd60eeae296db48a229ff71dfe071bde413e6d43f917dc8dcf8c78de33418906d11ac976abccb20b091292bff4ea897efcb639ea871cfa95f6de339e4b0a",
+		},
+	}
+
+	for _, vector := range vectors {
+		secretKey, _ := hex.DecodeString(vector.secretKey)
+		aux, _ := hex.DecodeString(vector.aux)
+		msg, _ := hex.DecodeString(vector.msg)
+		privateKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), secretKey)
+
+		assert.Equal(t, vector.publicKey, hex.EncodeToString(XOnly(privateKey.PubKey())), "Incorrect public key")
+
+		signature, err := Sign(privateKey, msg, aux)
+
+		assert.NoError(t, err, "Expected no error: valid key")
+		assert.Equal(t, vector.signature, hex.EncodeToString(signature), "Incorrect signature")
+
+		publicKey, _ := hex.DecodeString(vector.publicKey)
+
+		assert.True(t, Verify(publicKey, msg, signature), "Expected a valid signature")
+
+		signature[63] ^= 0x01
+
+		assert.False(t, Verify(publicKey, msg, signature), "Expected error: tampered signature")
+	}
+}
+
+func TestVerify_InvalidPublicKey(t *testing.T) {
+	// BIP340 test vector 5, public key not on the curve
+	var publicKey, _ = hex.DecodeString("eefdea4cdb677750a420fee807eacf21eb9898ae79b9768766e4faa04a2d4a34")
+	var msg, _ = hex.DecodeString("243f6a8885a308d313198a2e03707344a4093822299f31d0082efa98ec4e6c89")
+	var signature, _ = hex.DecodeString("6cff5c3ba86c69ea4b7376f31a9bcb4f74c1976089b2d9963da2e5543e17776969e89b4c5564d00349106b8497785dd7d1d713a8ae82b32fa79d5f7fc407d39b")
+
+	assert.False(t, Verify(publicKey, msg, signature), "Expected error: public key not on the curve")
+}
+
+func TestTweakPrivateKey(t *testing.T) {
+	// both parities of the internal key give the secret of the same output key
+	for _, secret := range []string{
+		"0000000000000000000000000000000000000000000000000000000000000003",
+		"b7e151628aed2a6abf7158809cf4f3c762e7160f38b4da56a784d9045190cfef",
+	} {
+		secretKey, _ := hex.DecodeString(secret)
+		privateKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), secretKey)
+
+		tweaked, err := TweakPrivateKey(privateKey, nil)
+
+		assert.NoError(t, err, "Expected no error: valid key")
+
+		outputKey, _ := OutputKey(privateKey.PubKey())
+
+		assert.Equal(t, outputKey, XOnly(tweaked.PubKey()), "Incorrect tweaked private key")
+	}
+}
@@ -0,0 +1,113 @@
+package taproot
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// SigHashDefault BIP341 default sighash type, committing to the transaction as SIGHASH_ALL does
+// with a 64 bytes signature
+const SigHashDefault txscript.SigHashType = 0x00
+
+// sigHashOutputMask bits of the sighash type selecting the outputs signed
+const sigHashOutputMask = 0x03
+
+var (
+	ErrPrevOutCount      = errors.New("one previous output is needed per input")
+	ErrInvalidSigHash    = errors.New("invalid taproot sighash type")
+	ErrSigHashSingleSpan = errors.New("SIGHASH_SINGLE input has no matching output")
+)
+
+// SignatureHash compute the BIP341 key path signature message hash of an input, prevOuts being the outputs
+// every input spends in input order
+func SignatureHash(tx *wire.MsgTx, index int, prevOuts []*wire.TxOut, hashType txscript.SigHashType) ([]byte, error) {
+	msg, err := signatureMessage(tx, index, prevOuts, hashType)
+	if err != nil {
+		return nil, err
+	}
+
+	return TaggedHash("TapSighash", msg), nil
+}
+
+// signatureMessage give the BIP341 key path signature message of an input, epoch byte included
+func signatureMessage(tx *wire.MsgTx, index int, prevOuts []*wire.TxOut, hashType txscript.SigHashType) ([]byte, error) {
+	if len(prevOuts) != len(tx.TxIn) || index < 0 || index >= len(tx.TxIn) {
+		return nil, ErrPrevOutCount
+	}
+	switch hashType {
+	case SigHashDefault, txscript.SigHashAll, txscript.SigHashNone, txscript.SigHashSingle,
+		txscript.SigHashAll | txscript.SigHashAnyOneCanPay,
+		txscript.SigHashNone | txscript.SigHashAnyOneCanPay,
+		txscript.SigHashSingle | txscript.SigHashAnyOneCanPay:
+	default:
+		return nil, ErrInvalidSigHash
+	}
+
+	var anyoneCanPay = hashType&txscript.SigHashAnyOneCanPay != 0
+	var outputType = hashType & sigHashOutputMask
+	if outputType == txscript.SigHashSingle && index >= len(tx.TxOut) {
+		return nil, ErrSigHashSingleSpan
+	}
+
+	// sighash epoch then the common signature message
+	var msg bytes.Buffer
+	msg.WriteByte(0x00)
+	msg.WriteByte(byte(hashType))
+	binary.Write(&msg, binary.LittleEndian, tx.Version)
+	binary.Write(&msg, binary.LittleEndian, tx.LockTime)
+
+	if !anyoneCanPay {
+		var prevouts, amounts, scriptPubKeys, sequences bytes.Buffer
+		for i, txIn := range tx.TxIn {
+			writeOutPoint(&prevouts, &txIn.PreviousOutPoint)
+			binary.Write(&amounts, binary.LittleEndian, prevOuts[i].Value)
+			wire.WriteVarBytes(&scriptPubKeys, 0, prevOuts[i].PkScript)
+			binary.Write(&sequences, binary.LittleEndian, txIn.Sequence)
+		}
+		msg.Write(sha256Sum(prevouts.Bytes()))
+		msg.Write(sha256Sum(amounts.Bytes()))
+		msg.Write(sha256Sum(scriptPubKeys.Bytes()))
+		msg.Write(sha256Sum(sequences.Bytes()))
+	}
+	if outputType != txscript.SigHashNone && outputType != txscript.SigHashSingle {
+		var outputs bytes.Buffer
+		for _, txOut := range tx.TxOut {
+			wire.WriteTxOut(&outputs, 0, 0, txOut)
+		}
+		msg.Write(sha256Sum(outputs.Bytes()))
+	}
+
+	// spend type, key path without annex
+	msg.WriteByte(0x00)
+	if anyoneCanPay {
+		writeOutPoint(&msg, &tx.TxIn[index].PreviousOutPoint)
+		binary.Write(&msg, binary.LittleEndian, prevOuts[index].Value)
+		wire.WriteVarBytes(&msg, 0, prevOuts[index].PkScript)
+		binary.Write(&msg, binary.LittleEndian, tx.TxIn[index].Sequence)
+	} else {
+		binary.Write(&msg, binary.LittleEndian, uint32(index))
+	}
+	if outputType == txscript.SigHashSingle {
+		var output bytes.Buffer
+		wire.WriteTxOut(&output, 0, 0, tx.TxOut[index])
+		msg.Write(sha256Sum(output.Bytes()))
+	}
+
+	return msg.Bytes(), nil
+}
+
+// writeOutPoint write the previous txid in its internal byte order and the output index
+func writeOutPoint(w *bytes.Buffer, outPoint *wire.OutPoint) {
+	w.Write(outPoint.Hash[:])
+	binary.Write(w, binary.LittleEndian, outPoint.Index)
+}
+
+func sha256Sum(data []byte) []byte {
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
@@ -0,0 +1,81 @@
+package taproot
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	"btcwalletapi/cryto/bech32m"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// WitnessVersion segwit version of taproot outputs
+const WitnessVersion = 1
+
+var ErrInvalidTweak = errors.New("taproot tweak out of range")
+
+// TaggedHash BIP340 tagged hash, sha256(sha256(tag) || sha256(tag) || msgs...)
+func TaggedHash(tag string, msgs ...[]byte) []byte {
+	tagHash := sha256.Sum256([]byte(tag))
+
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	for _, msg := range msgs {
+		h.Write(msg)
+	}
+	return h.Sum(nil)
+}
+
+// XOnly serialize the x coordinate of a public key on 32 bytes
+func XOnly(pubKey *btcec.PublicKey) []byte {
+	return pubKey.SerializeCompressed()[1:]
+}
+
+// hasEvenY tell whether the public key y coordinate is even
+func hasEvenY(pubKey *btcec.PublicKey) bool {
+	return pubKey.Y.Bit(0) == 0
+}
+
+// TweakPublicKey compute the BIP341 output key Q = P + int(hashTapTweak(x(P) || merkleRoot))G,
+// P being the internal key lifted to an even y, an empty merkle root commits to key path spending only
+func TweakPublicKey(internalKey *btcec.PublicKey, merkleRoot []byte) (*btcec.PublicKey, error) {
+	curve := btcec.S256()
+
+	px, py := new(big.Int).Set(internalKey.X), new(big.Int).Set(internalKey.Y)
+	if !hasEvenY(internalKey) {
+		py.Sub(curve.P, py)
+	}
+
+	t := new(big.Int).SetBytes(TaggedHash("TapTweak", XOnly(internalKey), merkleRoot))
+	if t.Cmp(curve.N) >= 0 {
+		return nil, ErrInvalidTweak
+	}
+
+	tx, ty := curve.ScalarBaseMult(t.Bytes())
+	qx, qy := curve.Add(px, py, tx, ty)
+
+	return &btcec.PublicKey{Curve: curve, X: qx, Y: qy}, nil
+}
+
+// OutputKey give the x-only output key of a BIP86 key path only taproot output
+func OutputKey(internalKey *btcec.PublicKey) ([]byte, error) {
+	outputKey, err := TweakPublicKey(internalKey, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return XOnly(outputKey), nil
+}
+
+// Address encode the BIP86 key path only pay-to-taproot address of an internal key
+func Address(internalKey *btcec.PublicKey, network *chaincfg.Params) (string, error) {
+	outputKey, err := OutputKey(internalKey)
+	if err != nil {
+		return "", err
+	}
+
+	return bech32m.EncodeSegWitAddress(network.Bech32HRPSegwit, WitnessVersion, outputKey)
+}
@@ -0,0 +1,38 @@
+package taproot
+
+import (
+	"encoding/hex"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAddress(t *testing.T) {
+	// BIP86 test vector m/86'/0'/0'/0/0
+	var internal, _ = hex.DecodeString("02cc8a4bc64d897bddc5fbc2f670f7a8ba0b386779106cf1223c6fc5d7cd6fc115")
+	var pubKey, err = btcec.ParsePubKey(internal, btcec.S256())
+
+	assert.NoError(t, err, "Expected no error: valid internal key")
+
+	outputKey, err := OutputKey(pubKey)
+
+	assert.NoError(t, err, "Expected no error: valid tweak")
+	assert.Equal(t, "a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c", hex.EncodeToString(outputKey), "Incorrect output key")
+
+	address, err := Address(pubKey, &chaincfg.MainNetParams)
+
+	assert.NoError(t, err, "Expected no error: valid address")
+	assert.Equal(t, "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr", address, "Incorrect taproot address")
+
+	// the internal key parity does not change the output key
+	internal[0] = 0x03
+	odd, err := btcec.ParsePubKey(internal, btcec.S256())
+
+	assert.NoError(t, err, "Expected no error: valid internal key")
+
+	oddOutputKey, err := OutputKey(odd)
+
+	assert.NoError(t, err, "Expected no error: valid tweak")
+	assert.Equal(t, outputKey, oddOutputKey, "Expected the even y lifted output key")
+}
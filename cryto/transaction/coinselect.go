@@ -0,0 +1,109 @@
+package transaction
+
+import (
+	"sort"
+)
+
+const (
+	// AlgorithmBranchAndBound exact match search, no change output
+	AlgorithmBranchAndBound = "branch_and_bound"
+	// AlgorithmLargestFirst largest effective values first, with a change output when worth it
+	AlgorithmLargestFirst = "largest_first"
+	// bnbMaxTries branch and bound search budget, as Bitcoin Core
+	bnbMaxTries = 100000
+)
+
+// candidate a spendable UTXO with the value left once its input fee is paid
+type candidate struct {
+	utxo           *UTXO
+	inputType      InputType
+	weight         int64
+	effectiveValue int64
+}
+
+// sortByEffectiveValue order candidates by descending effective value
+func sortByEffectiveValue(candidates []candidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].effectiveValue > candidates[j].effectiveValue
+	})
+}
+
+// selectBranchAndBound search the candidates whose effective values sum within [target, target+costOfChange],
+// the excess being cheaper given to the fee than spent on a change output, the smallest excess wins
+func selectBranchAndBound(candidates []candidate, target, costOfChange int64) ([]candidate, bool) {
+	sortByEffectiveValue(candidates)
+
+	var available int64
+	for _, c := range candidates {
+		available += c.effectiveValue
+	}
+	if available < target {
+		return nil, false
+	}
+
+	var tries int
+	var best, current []int
+	var bestExcess int64 = -1
+
+	var search func(depth int, value, remaining int64)
+	search = func(depth int, value, remaining int64) {
+		if tries >= bnbMaxTries || bestExcess == 0 {
+			return
+		}
+		tries++
+
+		switch {
+		case value > target+costOfChange, value+remaining < target:
+			// overshoot, or out of reach with the remaining candidates
+			return
+		case value >= target:
+			// adding candidates only grows the excess
+			if excess := value - target; bestExcess < 0 || excess < bestExcess {
+				best = append(best[:0], current...)
+				bestExcess = excess
+			}
+			return
+		case depth == len(candidates):
+			return
+		}
+
+		remaining -= candidates[depth].effectiveValue
+		// inclusion branch first
+		current = append(current, depth)
+		search(depth+1, value+candidates[depth].effectiveValue, remaining)
+		current = current[:len(current)-1]
+		// an omitted candidate makes the next one of equal value redundant
+		next := depth + 1
+		for next < len(candidates) && candidates[next].effectiveValue == candidates[depth].effectiveValue {
+			remaining -= candidates[next].effectiveValue
+			next++
+		}
+		search(next, value, remaining)
+	}
+	search(0, 0, available)
+
+	if bestExcess < 0 {
+		return nil, false
+	}
+
+	var selected = make([]candidate, len(best))
+	for i, index := range best {
+		selected[i] = candidates[index]
+	}
+	return selected, true
+}
+
+// selectLargestFirst pick candidates by descending effective value until target is reached
+func selectLargestFirst(candidates []candidate, target int64) ([]candidate, bool) {
+	sortByEffectiveValue(candidates)
+
+	var value int64
+	for i, c := range candidates {
+		value += c.effectiveValue
+		if value >= target {
+			return candidates[:i+1], true
+		}
+	}
+
+	return nil, false
+}
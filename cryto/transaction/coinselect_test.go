@@ -0,0 +1,54 @@
+package transaction
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func testCandidates(values ...int64) []candidate {
+	var candidates []candidate
+	for _, value := range values {
+		candidates = append(candidates, candidate{utxo: &UTXO{Value: value}, effectiveValue: value})
+	}
+	return candidates
+}
+
+func sumEffectiveValues(candidates []candidate) int64 {
+	var sum int64
+	for _, c := range candidates {
+		sum += c.effectiveValue
+	}
+	return sum
+}
+
+func TestSelectBranchAndBound(t *testing.T) {
+	var selected, ok = selectBranchAndBound(testCandidates(1, 2, 3, 4, 5), 10, 0)
+
+	assert.True(t, ok, "Expected an exact match")
+	assert.Equal(t, int64(10), sumEffectiveValues(selected), "Incorrect selection")
+
+	selected, ok = selectBranchAndBound(testCandidates(4, 6, 9), 11, 2)
+
+	assert.True(t, ok, "Expected a match within the cost of change")
+	assert.Equal(t, int64(13), sumEffectiveValues(selected), "Expected the smallest excess")
+
+	_, ok = selectBranchAndBound(testCandidates(4, 6, 9), 11, 1)
+
+	assert.False(t, ok, "Expected no match within the cost of change")
+
+	_, ok = selectBranchAndBound(testCandidates(1, 2), 10, 5)
+
+	assert.False(t, ok, "Expected no match: insufficient funds")
+}
+
+func TestSelectLargestFirst(t *testing.T) {
+	var selected, ok = selectLargestFirst(testCandidates(1, 8, 3, 5), 10)
+
+	assert.True(t, ok, "Expected a selection")
+	assert.Len(t, selected, 2, "Incorrect selection size")
+	assert.Equal(t, int64(13), sumEffectiveValues(selected), "Expected the largest values first")
+
+	_, ok = selectLargestFirst(testCandidates(1, 2), 10)
+
+	assert.False(t, ok, "Expected no selection: insufficient funds")
+}
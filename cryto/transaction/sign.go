@@ -0,0 +1,142 @@
+package transaction
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+
+	"btcwalletapi/cryto/segwit"
+	"btcwalletapi/cryto/taproot"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+var (
+	ErrPrevOutCount      = errors.New("one previous output is needed per input")
+	ErrKeyMismatch       = errors.New("key derived at the path does not own the previous output")
+	ErrUnsupportedSigner = errors.New("only P2PKH, P2SH-P2WPKH, P2WPKH and P2TR inputs can be signed, use a PSBT for multisig")
+)
+
+// PrevOut output an input spends, with the absolute derivation path of the key owning it
+type PrevOut struct {
+	Value        int64
+	ScriptPubKey []byte
+	Path         []uint32
+}
+
+// Sign sign every input of a transaction with the key the key manager derives at its previous output path,
+// P2PKH, P2SH-P2WPKH and P2WPKH inputs with SIGHASH_ALL, P2TR inputs on their BIP86 key path with SIGHASH_DEFAULT
+func Sign(tx *wire.MsgTx, prevOuts []PrevOut, km *segwit.KeyManager) error {
+	if len(prevOuts) != len(tx.TxIn) {
+		return ErrPrevOutCount
+	}
+
+	var txOuts = make([]*wire.TxOut, len(prevOuts))
+	for i, prevOut := range prevOuts {
+		txOuts[i] = wire.NewTxOut(prevOut.Value, prevOut.ScriptPubKey)
+	}
+	var sigHashes = txscript.NewTxSigHashes(tx)
+
+	for i, prevOut := range prevOuts {
+		key, err := km.KeyAt(prevOut.Path)
+		if err != nil {
+			return err
+		}
+		inputType, err := ClassifyScript(prevOut.ScriptPubKey)
+		if err != nil {
+			return err
+		}
+
+		var privateKey = key.PrivateKey()
+		switch inputType {
+		case InputTypeP2PKH:
+			publicKey, ok := keyHashOwner(key, prevOut.ScriptPubKey[3:23])
+			if !ok {
+				return ErrKeyMismatch
+			}
+			signature, err := txscript.RawTxInSignature(tx, i, prevOut.ScriptPubKey, txscript.SigHashAll, privateKey)
+			if err != nil {
+				return err
+			}
+			tx.TxIn[i].SignatureScript, err = txscript.NewScriptBuilder().AddData(signature).AddData(publicKey).Script()
+			if err != nil {
+				return err
+			}
+
+		case InputTypeP2WPKH:
+			publicKey, ok := keyHashOwner(key, prevOut.ScriptPubKey[2:])
+			if !ok {
+				return ErrKeyMismatch
+			}
+			signature, err := txscript.RawTxInWitnessSignature(tx, sigHashes, i, prevOut.Value, prevOut.ScriptPubKey, txscript.SigHashAll, privateKey)
+			if err != nil {
+				return err
+			}
+			tx.TxIn[i].Witness = wire.TxWitness{signature, publicKey}
+
+		case InputTypeP2SHP2WPKH:
+			publicKey := key.PublicKey(true)
+			redeemScript := append([]byte{txscript.OP_0, txscript.OP_DATA_20}, btcutil.Hash160(publicKey)...)
+			if !bytes.Equal(btcutil.Hash160(redeemScript), prevOut.ScriptPubKey[2:22]) {
+				return ErrKeyMismatch
+			}
+			signature, err := txscript.RawTxInWitnessSignature(tx, sigHashes, i, prevOut.Value, redeemScript, txscript.SigHashAll, privateKey)
+			if err != nil {
+				return err
+			}
+			tx.TxIn[i].SignatureScript, err = txscript.NewScriptBuilder().AddData(redeemScript).Script()
+			if err != nil {
+				return err
+			}
+			tx.TxIn[i].Witness = wire.TxWitness{signature, publicKey}
+
+		case InputTypeP2TR:
+			signature, err := signTaprootKeyPath(tx, i, txOuts, privateKey)
+			if err != nil {
+				return err
+			}
+			tx.TxIn[i].Witness = wire.TxWitness{signature}
+
+		default:
+			return ErrUnsupportedSigner
+		}
+	}
+
+	return nil
+}
+
+// keyHashOwner give the compressed, then uncompressed, serialization of the key hashing to a key hash
+func keyHashOwner(key *segwit.Key, keyHash []byte) ([]byte, bool) {
+	for _, compress := range []bool{true, false} {
+		publicKey := key.PublicKey(compress)
+		if bytes.Equal(btcutil.Hash160(publicKey), keyHash) {
+			return publicKey, true
+		}
+	}
+	return nil, false
+}
+
+// signTaprootKeyPath sign a BIP86 taproot input with the tweaked private key and fresh auxiliary randomness
+func signTaprootKeyPath(tx *wire.MsgTx, index int, prevOuts []*wire.TxOut, privateKey *btcec.PrivateKey) ([]byte, error) {
+	tweaked, err := taproot.TweakPrivateKey(privateKey, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(taproot.XOnly(tweaked.PubKey()), prevOuts[index].PkScript[2:]) {
+		return nil, ErrKeyMismatch
+	}
+
+	sigHash, err := taproot.SignatureHash(tx, index, prevOuts, taproot.SigHashDefault)
+	if err != nil {
+		return nil, err
+	}
+
+	var aux [32]byte
+	if _, err := rand.Read(aux[:]); err != nil {
+		return nil, err
+	}
+
+	return taproot.Sign(tweaked, sigHash, aux[:])
+}
@@ -0,0 +1,91 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"btcwalletapi/cryto/segwit"
+	"btcwalletapi/cryto/taproot"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+// testSignPrevOuts outputs of the abandon ... about mnemonic, the BIP44, BIP49, BIP84 and BIP86 first receive addresses
+func testSignPrevOuts() []PrevOut {
+	script := func(s string) []byte {
+		b, _ := hex.DecodeString(s)
+		return b
+	}
+
+	return []PrevOut{
+		{Value: 10000, ScriptPubKey: script("76a914d986ed01b7a22225a70edbf2ba7cfb63a15cb3aa88ac"), Path: []uint32{segwit.PurposeBIP44, segwit.CoinTypeBTC, segwit.Apostrophe, 0, 0}},
+		{Value: 20000, ScriptPubKey: script("a9143fb6e95812e57bb4691f9a4a628862a61a4f769b87"), Path: []uint32{segwit.PurposeBIP49, segwit.CoinTypeBTC, segwit.Apostrophe, 0, 0}},
+		{Value: 30000, ScriptPubKey: script("0014c0cebcd6c3d3ca8c75dc5ec62ebe55330ef910e2"), Path: []uint32{segwit.PurposeBIP84, segwit.CoinTypeBTC, segwit.Apostrophe, 0, 0}},
+		{Value: 40000, ScriptPubKey: script("5120a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c"), Path: []uint32{segwit.PurposeBIP86, segwit.CoinTypeBTC, segwit.Apostrophe, 0, 0}},
+	}
+}
+
+func testSignTx(count int) *wire.MsgTx {
+	var tx = wire.NewMsgTx(txVersion)
+	for i := 0; i < count; i++ {
+		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{byte(i + 1)}, uint32(i)), nil, nil))
+	}
+	tx.AddTxOut(wire.NewTxOut(90000, testSignPrevOuts()[2].ScriptPubKey))
+	return tx
+}
+
+func TestSign(t *testing.T) {
+	var seed, _ = hex.DecodeString("5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4")
+	var km, _ = segwit.NewKeyManager(seed, &chaincfg.MainNetParams)
+	var prevOuts = testSignPrevOuts()
+	var tx = testSignTx(len(prevOuts))
+
+	var err = Sign(tx, prevOuts, km)
+
+	assert.NoError(t, err, "Expected no error: keys owning every output")
+
+	// segwit v0 and legacy inputs pass the script engine
+	var sigHashes = txscript.NewTxSigHashes(tx)
+	for i, prevOut := range prevOuts[:3] {
+		engine, err := txscript.NewEngine(prevOut.ScriptPubKey, tx, i, txscript.StandardVerifyFlags, nil, sigHashes, prevOut.Value)
+		assert.NoError(t, err, "Expected no error: valid engine")
+		assert.NoError(t, engine.Execute(), "Expected a valid input %d", i)
+	}
+
+	// the taproot key path signature verifies against the output key
+	var txOuts []*wire.TxOut
+	for _, prevOut := range prevOuts {
+		txOuts = append(txOuts, wire.NewTxOut(prevOut.Value, prevOut.ScriptPubKey))
+	}
+	sigHash, err := taproot.SignatureHash(tx, 3, txOuts, taproot.SigHashDefault)
+
+	assert.NoError(t, err, "Expected no error: valid input")
+	assert.Len(t, tx.TxIn[3].Witness, 1, "Expected a key path witness")
+	assert.True(t, taproot.Verify(prevOuts[3].ScriptPubKey[2:], sigHash, tx.TxIn[3].Witness[0]), "Expected a valid schnorr signature")
+}
+
+func TestSign_Errors(t *testing.T) {
+	var seed, _ = hex.DecodeString("5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4")
+	var km, _ = segwit.NewKeyManager(seed, &chaincfg.MainNetParams)
+	var prevOuts = testSignPrevOuts()
+
+	var err = Sign(testSignTx(2), prevOuts, km)
+
+	assert.Equal(t, ErrPrevOutCount, err, "Expected error: a previous output per input")
+
+	// the BIP84 key does not own the BIP44 output
+	prevOuts[0].Path = prevOuts[2].Path
+	err = Sign(testSignTx(len(prevOuts)), prevOuts, km)
+
+	assert.Equal(t, ErrKeyMismatch, err, "Expected error: key not owning the output")
+
+	// a P2WSH output needs a PSBT
+	prevOuts = testSignPrevOuts()[2:3]
+	prevOuts[0].ScriptPubKey = append([]byte{txscript.OP_0, txscript.OP_DATA_32}, make([]byte, 32)...)
+	err = Sign(testSignTx(1), prevOuts, km)
+
+	assert.Equal(t, ErrUnsupportedSigner, err, "Expected error: multisig input")
+}
@@ -0,0 +1,207 @@
+package transaction
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+
+	"btcwalletapi/cryto/multisig"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+// InputType kind of output an input spends, deciding its size
+type InputType string
+
+const (
+	InputTypeP2PKH      InputType = "p2pkh"
+	InputTypeP2SH       InputType = "p2sh"
+	InputTypeP2SHP2WPKH InputType = "p2sh-p2wpkh"
+	InputTypeP2WPKH     InputType = "p2wpkh"
+	InputTypeP2WSH      InputType = "p2wsh"
+	InputTypeP2SHP2WSH  InputType = "p2sh-p2wsh"
+	InputTypeP2TR       InputType = "p2tr"
+)
+
+const (
+	// witnessScaleFactor weight of a non witness byte
+	witnessScaleFactor = 4
+	// outPointSize previous txid and vout
+	outPointSize = 32 + 4
+	// sequenceSize nSequence
+	sequenceSize = 4
+	// valueSize output amount
+	valueSize = 8
+	// overheadSize nVersion and nLockTime
+	overheadSize = 4 + 4
+	// segwitMarkerWeight marker and flag bytes of a segwit serialization
+	segwitMarkerWeight = 2
+	// signatureSize largest DER ECDSA signature with its sighash byte
+	signatureSize = 72
+	// schnorrSignatureSize BIP340 signature with the default sighash
+	schnorrSignatureSize = 64
+	// compressedPubKeySize serialized compressed public key
+	compressedPubKeySize = 33
+	// p2pkhScriptSigSize <sig> <pubkey>
+	p2pkhScriptSigSize = 1 + signatureSize + 1 + compressedPubKeySize
+	// p2shMultisigDummySize OP_0 consumed by the CHECKMULTISIG off by one
+	p2shMultisigDummySize = 1
+	// p2shP2WPKHScriptSigSize push of OP_0 <20 bytes key hash>
+	p2shP2WPKHScriptSigSize = 1 + 22
+	// p2shP2WSHScriptSigSize push of OP_0 <32 bytes script hash>
+	p2shP2WSHScriptSigSize = 1 + 34
+	// p2wpkhWitnessSize <count> <sig> <pubkey>
+	p2wpkhWitnessSize = 1 + 1 + signatureSize + 1 + compressedPubKeySize
+	// p2trWitnessSize <count> <schnorr sig>
+	p2trWitnessSize = 1 + 1 + schnorrSignatureSize
+)
+
+var (
+	ErrUnsupportedScript    = errors.New("unsupported scriptPubKey, expected P2PKH, P2SH-P2WPKH, P2SH-P2WSH, P2WPKH, P2WSH or P2TR")
+	ErrMissingWitnessScript = errors.New("P2WSH and P2SH-P2WSH inputs need their multisig witness script")
+	ErrMissingRedeemScript  = errors.New("P2SH inputs need their redeem script or witness script")
+	ErrRedeemScriptMismatch = errors.New("redeem script does not match the P2SH scriptPubKey")
+)
+
+// ClassifyScript give the input type spending a scriptPubKey, P2SH is taken as the P2SH-P2WPKH of the wallet keys,
+// UTXOs of unknown P2SH scripts are classified from their redeem script, see classifyInput
+func ClassifyScript(scriptPubKey []byte) (InputType, error) {
+	switch txscript.GetScriptClass(scriptPubKey) {
+	case txscript.PubKeyHashTy:
+		return InputTypeP2PKH, nil
+	case txscript.ScriptHashTy:
+		return InputTypeP2SHP2WPKH, nil
+	case txscript.WitnessV0PubKeyHashTy:
+		return InputTypeP2WPKH, nil
+	case txscript.WitnessV0ScriptHashTy:
+		return InputTypeP2WSH, nil
+	}
+	// OP_1 <32 bytes output key>
+	if len(scriptPubKey) == 34 && scriptPubKey[0] == txscript.OP_1 && scriptPubKey[1] == txscript.OP_DATA_32 {
+		return InputTypeP2TR, nil
+	}
+
+	return "", ErrUnsupportedScript
+}
+
+// classifyInput give the input type spending a UTXO, P2SH being classified from its redeem or witness script
+func classifyInput(utxo *UTXO) (InputType, error) {
+	inputType, err := ClassifyScript(utxo.ScriptPubKey)
+	if err != nil {
+		return "", err
+	}
+	if inputType != InputTypeP2SHP2WPKH {
+		return inputType, nil
+	}
+
+	// P2SH-P2WSH, the redeem script being the P2WSH program of the witness script
+	if len(utxo.WitnessScript) > 0 {
+		if len(utxo.RedeemScript) > 0 {
+			program := sha256.Sum256(utxo.WitnessScript)
+			if !bytes.Equal(utxo.RedeemScript, append([]byte{txscript.OP_0, txscript.OP_DATA_32}, program[:]...)) {
+				return "", ErrWitnessScriptMismatch
+			}
+		}
+		return InputTypeP2SHP2WSH, nil
+	}
+	if len(utxo.RedeemScript) == 0 {
+		return "", ErrMissingRedeemScript
+	}
+	if !bytes.Equal(btcutil.Hash160(utxo.RedeemScript), utxo.ScriptPubKey[2:22]) {
+		return "", ErrRedeemScriptMismatch
+	}
+	switch txscript.GetScriptClass(utxo.RedeemScript) {
+	case txscript.WitnessV0PubKeyHashTy:
+		return InputTypeP2SHP2WPKH, nil
+	case txscript.WitnessV0ScriptHashTy:
+		return "", ErrMissingWitnessScript
+	}
+
+	return InputTypeP2SH, nil
+}
+
+// isWitness tell whether the input type spends from the witness
+func (t InputType) isWitness() bool {
+	return t != InputTypeP2PKH && t != InputTypeP2SH
+}
+
+// inputWeight weight of a signed input spending the input type, its witness included,
+// script being the witness script of P2WSH and P2SH-P2WSH or the redeem script of P2SH
+func inputWeight(inputType InputType, script []byte) (int64, error) {
+	var scriptSigSize, witnessSize int64
+
+	switch inputType {
+	case InputTypeP2PKH:
+		scriptSigSize = p2pkhScriptSigSize
+	case InputTypeP2SH:
+		// <empty dummy> <sig>... <redeem script>
+		if len(script) == 0 {
+			return 0, ErrMissingRedeemScript
+		}
+		decoded, err := multisig.ParseScript(script)
+		if err != nil {
+			return 0, err
+		}
+		scriptSigSize = int64(p2shMultisigDummySize + decoded.M*(1+signatureSize) + pushDataSize(len(script)) + len(script))
+	case InputTypeP2SHP2WPKH:
+		scriptSigSize = p2shP2WPKHScriptSigSize
+		witnessSize = p2wpkhWitnessSize
+	case InputTypeP2WPKH:
+		witnessSize = p2wpkhWitnessSize
+	case InputTypeP2TR:
+		witnessSize = p2trWitnessSize
+	case InputTypeP2WSH, InputTypeP2SHP2WSH:
+		// <count> <empty dummy> <sig>... <witness script>
+		if inputType == InputTypeP2SHP2WSH {
+			scriptSigSize = p2shP2WSHScriptSigSize
+		}
+		if len(script) == 0 {
+			return 0, ErrMissingWitnessScript
+		}
+		decoded, err := multisig.ParseScript(script)
+		if err != nil {
+			return 0, err
+		}
+		witnessSize = int64(1 + 1 + decoded.M*(1+signatureSize) + wire.VarIntSerializeSize(uint64(len(script))) + len(script))
+	default:
+		return 0, ErrUnsupportedScript
+	}
+
+	baseSize := outPointSize + int64(wire.VarIntSerializeSize(uint64(scriptSigSize))) + scriptSigSize + sequenceSize
+	return baseSize*witnessScaleFactor + witnessSize, nil
+}
+
+// pushDataSize size of the opcode pushing data of a length in a scriptSig
+func pushDataSize(length int) int {
+	switch {
+	case length < txscript.OP_PUSHDATA1:
+		return 1
+	case length <= 0xff:
+		return 2
+	case length <= 0xffff:
+		return 3
+	}
+	return 5
+}
+
+// outputWeight weight of an output paying to a scriptPubKey
+func outputWeight(scriptPubKey []byte) int64 {
+	size := valueSize + wire.VarIntSerializeSize(uint64(len(scriptPubKey))) + len(scriptPubKey)
+	return int64(size) * witnessScaleFactor
+}
+
+// overheadWeight weight of the transaction fields besides inputs and outputs
+func overheadWeight(inputCount, outputCount int, segwit bool) int64 {
+	size := overheadSize + wire.VarIntSerializeSize(uint64(inputCount)) + wire.VarIntSerializeSize(uint64(outputCount))
+	weight := int64(size) * witnessScaleFactor
+	if segwit {
+		weight += segwitMarkerWeight
+	}
+	return weight
+}
+
+// VirtualSize give the virtual size in vbytes of a weight, rounded up
+func VirtualSize(weight int64) int64 {
+	return (weight + witnessScaleFactor - 1) / witnessScaleFactor
+}
@@ -0,0 +1,62 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestClassifyScript(t *testing.T) {
+	var cases = map[string]InputType{
+		"76a91477bff20c60e522dfaa3350c39b030a5d004e839a88ac":                   InputTypeP2PKH,
+		"a914b472a266d0bd89c13706a4132ccfb16f7c3b9fcb87":                       InputTypeP2SHP2WPKH,
+		"0014751e76e8199196d454941c45d1b3a323f1433bd6":                         InputTypeP2WPKH,
+		"00201863143c14c5166804bd19203356da136c985678cd4d27a1b8c6329604903262": InputTypeP2WSH,
+		"512079be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798": InputTypeP2TR,
+	}
+
+	for scriptHex, expected := range cases {
+		script, _ := hex.DecodeString(scriptHex)
+		inputType, err := ClassifyScript(script)
+
+		assert.NoError(t, err, "Expected no error: supported script "+scriptHex)
+		assert.Equal(t, expected, inputType, "Incorrect input type "+scriptHex)
+	}
+
+	var _, err = ClassifyScript([]byte{0x6a})
+
+	assert.Equal(t, ErrUnsupportedScript, err, "Expected error: OP_RETURN script")
+}
+
+func TestInputWeight(t *testing.T) {
+	var cases = map[InputType]int64{
+		InputTypeP2PKH:      148,
+		InputTypeP2SHP2WPKH: 91,
+		InputTypeP2WPKH:     68,
+		InputTypeP2TR:       58,
+	}
+
+	for inputType, expected := range cases {
+		weight, err := inputWeight(inputType, nil)
+
+		assert.NoError(t, err, "Expected no error: supported input type")
+		assert.Equal(t, expected, VirtualSize(weight), "Incorrect vsize "+string(inputType))
+	}
+
+	var _, err = inputWeight(InputTypeP2WSH, nil)
+
+	assert.Equal(t, ErrMissingWitnessScript, err, "Expected error: P2WSH without witness script")
+
+	// 2-of-3 compressed keys witness script
+	witnessScript, _ := hex.DecodeString("522103a882d414e478039cd5b52a92ffb13dd5e6bd4515497439dffd691a0f12af957521036ce31db9bdd543e72fe3039a1f1c047dab87037c36a669ff90e28da1848f640d210311ffd36c70776538d079fbae117dc38effafb33304af83ce4894589747aee1ef53ae")
+	weight, err := inputWeight(InputTypeP2WSH, witnessScript)
+
+	assert.NoError(t, err, "Expected no error: multisig witness script")
+	assert.Equal(t, int64(41*4+1+1+2*73+1+105), weight, "Incorrect P2WSH weight")
+
+	// same script nested in P2SH, its 35 bytes scriptSig pushes the P2WSH program
+	weight, err = inputWeight(InputTypeP2SHP2WSH, witnessScript)
+
+	assert.NoError(t, err, "Expected no error: multisig witness script")
+	assert.Equal(t, int64(76*4+1+1+2*73+1+105), weight, "Incorrect P2SH-P2WSH weight")
+}
@@ -0,0 +1,292 @@
+package transaction
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"math"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+)
+
+const (
+	// txVersion nVersion of built transactions
+	txVersion = 2
+	// rbfSequence nSequence signaling BIP125 replaceability
+	rbfSequence = wire.MaxTxInSequenceNum - 2
+	// dustRelayFeeRate sat/vB rate dust outputs are measured with
+	dustRelayFeeRate = 3
+	// legacySpendSize and witnessSpendSize vbytes spending an output, as the dust rule assumes
+	legacySpendSize  = 148
+	witnessSpendSize = 67
+)
+
+var (
+	ErrNoUTXOs               = errors.New("no UTXO to spend")
+	ErrNoOutputs             = errors.New("no output to pay")
+	ErrInvalidFeeRate        = errors.New("fee rate must be positive")
+	ErrInvalidValue          = errors.New("values must be positive")
+	ErrInvalidTxID           = errors.New("invalid UTXO txid")
+	ErrDustOutput            = errors.New("output value below the dust threshold")
+	ErrInsufficientFunds     = errors.New("insufficient funds for the outputs and the fee")
+	ErrWitnessScriptMismatch = errors.New("witness script does not match the P2WSH or P2SH-P2WSH scriptPubKey")
+	ErrDuplicateUTXO         = errors.New("UTXO outpoint given more than once")
+	ErrValueTooLarge         = errors.New("value above the 21 million bitcoin supply")
+)
+
+// UTXO an unspent output to fund the transaction with
+type UTXO struct {
+	// TxID previous transaction id, in its usual byte reversed hex form
+	TxID         string
+	Vout         uint32
+	Value        int64
+	ScriptPubKey []byte
+	// WitnessScript multisig witness script of P2WSH and P2SH-P2WSH outputs
+	WitnessScript []byte
+	// RedeemScript redeem script of P2SH outputs, a P2WPKH program or a legacy multisig script
+	RedeemScript []byte
+	// Path optional derivation path of the key owning the output
+	Path string
+}
+
+// Output a payment of the transaction
+type Output struct {
+	Address      string
+	ScriptPubKey []byte
+	Value        int64
+}
+
+// UnsignedTransaction an unsigned transaction with the inputs it spends and its fee breakdown
+type UnsignedTransaction struct {
+	Tx *wire.MsgTx
+	// Inputs UTXOs spent, in input order
+	Inputs []*UTXO
+	// Change output paid back to the wallet, nil when the excess went to the fee
+	Change      *Output
+	Algorithm   string
+	InputTotal  int64
+	OutputTotal int64
+	Fee         int64
+	// VirtualSize estimated signed transaction size in vbytes
+	VirtualSize int64
+	FeeRate     float64
+}
+
+// fee give the fee of a weight at a sat/vB rate, rounded up
+func fee(weight int64, feeRate float64) int64 {
+	return int64(math.Ceil(float64(weight) * feeRate / witnessScaleFactor))
+}
+
+// dustThreshold smallest value worth paying to a scriptPubKey
+func dustThreshold(scriptPubKey []byte) int64 {
+	spendSize := int64(legacySpendSize)
+	if txscript.IsWitnessProgram(scriptPubKey) {
+		spendSize = witnessSpendSize
+	}
+
+	return (VirtualSize(outputWeight(scriptPubKey)) + spendSize) * dustRelayFeeRate
+}
+
+// newCandidate check a UTXO and give its weight and effective value at the fee rate
+func newCandidate(utxo *UTXO, feeRate float64) (candidate, error) {
+	if utxo.Value <= 0 {
+		return candidate{}, ErrInvalidValue
+	}
+	if utxo.Value > btcutil.MaxSatoshi {
+		return candidate{}, ErrValueTooLarge
+	}
+	if _, err := chainhash.NewHashFromStr(utxo.TxID); err != nil || len(utxo.TxID) != chainhash.MaxHashStringSize {
+		return candidate{}, ErrInvalidTxID
+	}
+
+	inputType, err := classifyInput(utxo)
+	if err != nil {
+		return candidate{}, err
+	}
+	if len(utxo.WitnessScript) > 0 {
+		program := sha256.Sum256(utxo.WitnessScript)
+		switch inputType {
+		case InputTypeP2WSH:
+			if !bytes.Equal(program[:], utxo.ScriptPubKey[2:]) {
+				return candidate{}, ErrWitnessScriptMismatch
+			}
+		case InputTypeP2SHP2WSH:
+			// the P2SH redeem script is the P2WSH program OP_0 <sha256(witness script)>
+			redeemScript := append([]byte{txscript.OP_0, txscript.OP_DATA_32}, program[:]...)
+			if !bytes.Equal(btcutil.Hash160(redeemScript), utxo.ScriptPubKey[2:22]) {
+				return candidate{}, ErrWitnessScriptMismatch
+			}
+		}
+	}
+
+	var script = utxo.WitnessScript
+	if inputType == InputTypeP2SH {
+		script = utxo.RedeemScript
+	}
+	weight, err := inputWeight(inputType, script)
+	if err != nil {
+		return candidate{}, err
+	}
+	// a legacy input in a segwit transaction still serializes an empty witness
+	if !inputType.isWitness() {
+		weight++
+	}
+
+	return candidate{utxo: utxo, inputType: inputType, weight: weight, effectiveValue: utxo.Value - fee(weight, feeRate)}, nil
+}
+
+// Build select UTXOs paying the outputs at a sat/vB fee rate, branch and bound first then largest first,
+// and build the unsigned transaction, the excess above the dust threshold is paid to the change scriptPubKey
+func Build(utxos []UTXO, outputs []Output, feeRate float64, change Output) (*UnsignedTransaction, error) {
+	if len(utxos) == 0 {
+		return nil, ErrNoUTXOs
+	}
+	if len(outputs) == 0 {
+		return nil, ErrNoOutputs
+	}
+	if feeRate <= 0 || math.IsNaN(feeRate) || math.IsInf(feeRate, 0) {
+		return nil, ErrInvalidFeeRate
+	}
+
+	// outputs and the transaction overhead, the segwit marker counted to stay conservative
+	var outputTotal int64
+	var baseWeight = overheadWeight(len(utxos), len(outputs)+1, true)
+	for _, output := range outputs {
+		if output.Value <= 0 {
+			return nil, ErrInvalidValue
+		}
+		if output.Value > btcutil.MaxSatoshi {
+			return nil, ErrValueTooLarge
+		}
+		if output.Value < dustThreshold(output.ScriptPubKey) {
+			return nil, ErrDustOutput
+		}
+		// bounded values keep the totals far from overflowing int64
+		outputTotal += output.Value
+		if outputTotal > btcutil.MaxSatoshi {
+			return nil, ErrValueTooLarge
+		}
+		baseWeight += outputWeight(output.ScriptPubKey)
+	}
+	var target = outputTotal + fee(baseWeight, feeRate)
+
+	// spending the change later costs as much as creating it
+	changeInputType, err := ClassifyScript(change.ScriptPubKey)
+	if err != nil {
+		return nil, err
+	}
+	changeSpendWeight, err := inputWeight(changeInputType, nil)
+	if err != nil {
+		return nil, err
+	}
+	var changeFee = fee(outputWeight(change.ScriptPubKey), feeRate)
+	var costOfChange = changeFee + fee(changeSpendWeight, feeRate)
+
+	// only UTXOs worth more than their input fee are candidates, an outpoint can be spent once
+	var candidates []candidate
+	var utxoTotal int64
+	var outpoints = make(map[wire.OutPoint]bool, len(utxos))
+	for i := range utxos {
+		c, err := newCandidate(&utxos[i], feeRate)
+		if err != nil {
+			return nil, err
+		}
+		utxoTotal += utxos[i].Value
+		if utxoTotal > btcutil.MaxSatoshi {
+			return nil, ErrValueTooLarge
+		}
+		hash, _ := chainhash.NewHashFromStr(utxos[i].TxID)
+		outpoint := *wire.NewOutPoint(hash, utxos[i].Vout)
+		if outpoints[outpoint] {
+			return nil, ErrDuplicateUTXO
+		}
+		outpoints[outpoint] = true
+		if c.effectiveValue > 0 {
+			candidates = append(candidates, c)
+		}
+	}
+
+	var algorithm = AlgorithmBranchAndBound
+	selected, ok := selectBranchAndBound(candidates, target, costOfChange)
+	if !ok {
+		algorithm = AlgorithmLargestFirst
+		selected, ok = selectLargestFirst(candidates, target+changeFee)
+		if !ok {
+			selected, ok = selectLargestFirst(candidates, target)
+		}
+	}
+	if !ok {
+		return nil, ErrInsufficientFunds
+	}
+
+	return assemble(selected, outputs, feeRate, change, algorithm)
+}
+
+// assemble build the transaction of selected inputs, adding the change output when it is above the dust threshold
+func assemble(selected []candidate, outputs []Output, feeRate float64, change Output, algorithm string) (*UnsignedTransaction, error) {
+	var result = &UnsignedTransaction{Tx: wire.NewMsgTx(txVersion), Algorithm: algorithm, FeeRate: feeRate}
+
+	var inputWeights int64
+	var segwit bool
+	for _, c := range selected {
+		hash, err := chainhash.NewHashFromStr(c.utxo.TxID)
+		if err != nil {
+			return nil, ErrInvalidTxID
+		}
+		input := wire.NewTxIn(wire.NewOutPoint(hash, c.utxo.Vout), nil, nil)
+		input.Sequence = rbfSequence
+		result.Tx.AddTxIn(input)
+		result.Inputs = append(result.Inputs, c.utxo)
+		result.InputTotal += c.utxo.Value
+
+		inputWeights += c.weight
+		if c.inputType.isWitness() {
+			segwit = true
+		}
+	}
+	if !segwit {
+		// no empty witness serialized without witness inputs
+		inputWeights -= int64(len(selected))
+	}
+
+	var outputWeights int64
+	for _, output := range outputs {
+		result.Tx.AddTxOut(wire.NewTxOut(output.Value, output.ScriptPubKey))
+		result.OutputTotal += output.Value
+		outputWeights += outputWeight(output.ScriptPubKey)
+	}
+
+	// weight and fee without then with the change output
+	var weight = overheadWeight(len(selected), len(outputs), segwit) + inputWeights + outputWeights
+	var changeWeight = overheadWeight(len(selected), len(outputs)+1, segwit) + inputWeights + outputWeights + outputWeight(change.ScriptPubKey)
+	if result.InputTotal-result.OutputTotal < fee(weight, feeRate) {
+		return nil, ErrInsufficientFunds
+	}
+
+	var changeValue = result.InputTotal - result.OutputTotal - fee(changeWeight, feeRate)
+	if algorithm != AlgorithmBranchAndBound && changeValue >= dustThreshold(change.ScriptPubKey) {
+		change.Value = changeValue
+		result.Tx.AddTxOut(wire.NewTxOut(change.Value, change.ScriptPubKey))
+		result.Change = &change
+		result.OutputTotal += change.Value
+		weight = changeWeight
+	}
+
+	result.Fee = result.InputTotal - result.OutputTotal
+	result.VirtualSize = VirtualSize(weight)
+
+	return result, nil
+}
+
+// Serialize give the unsigned transaction in its network serialization
+func (u *UnsignedTransaction) Serialize() ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := u.Tx.Serialize(&buffer); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,203 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"github.com/btcsuite/btcutil"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+var (
+	testTxID         = "4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b"
+	testP2WPKHScript = mustDecodeHex("0014751e76e8199196d454941c45d1b3a323f1433bd6")
+	testChangeScript = mustDecodeHex("00141863143c14c5166804bd19203356da136c985678")
+)
+
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestBuild_BranchAndBound(t *testing.T) {
+	var utxos = []UTXO{
+		{TxID: testTxID, Vout: 0, Value: 500000, ScriptPubKey: testP2WPKHScript},
+		{TxID: testTxID, Vout: 1, Value: 100110, ScriptPubKey: testP2WPKHScript},
+	}
+	var outputs = []Output{{ScriptPubKey: testP2WPKHScript, Value: 100000}}
+
+	var tx, err = Build(utxos, outputs, 1, Output{ScriptPubKey: testChangeScript})
+
+	assert.NoError(t, err, "Expected no error: enough funds")
+	assert.Equal(t, AlgorithmBranchAndBound, tx.Algorithm, "Expected an exact match")
+	assert.Len(t, tx.Inputs, 1, "Incorrect input count")
+	assert.Equal(t, uint32(1), tx.Inputs[0].Vout, "Expected the exact match UTXO")
+	assert.Nil(t, tx.Change, "Expected no change output")
+	assert.Equal(t, int64(110), tx.Fee, "Incorrect fee")
+	assert.Equal(t, int64(110), tx.VirtualSize, "Incorrect vsize")
+	assert.Len(t, tx.Tx.TxOut, 1, "Incorrect output count")
+}
+
+func TestBuild_LargestFirst(t *testing.T) {
+	var utxos = []UTXO{
+		{TxID: testTxID, Vout: 0, Value: 100110, ScriptPubKey: testP2WPKHScript},
+		{TxID: testTxID, Vout: 1, Value: 500000, ScriptPubKey: testP2WPKHScript},
+	}
+	var outputs = []Output{{ScriptPubKey: testP2WPKHScript, Value: 300000}}
+
+	var tx, err = Build(utxos, outputs, 1, Output{Address: "change", ScriptPubKey: testChangeScript})
+
+	assert.NoError(t, err, "Expected no error: enough funds")
+	assert.Equal(t, AlgorithmLargestFirst, tx.Algorithm, "Expected the fallback algorithm")
+	assert.Len(t, tx.Inputs, 1, "Incorrect input count")
+	assert.Equal(t, int64(500000), tx.InputTotal, "Incorrect input total")
+	assert.Equal(t, int64(141), tx.Fee, "Incorrect fee")
+	assert.Equal(t, int64(141), tx.VirtualSize, "Incorrect vsize")
+	assert.Equal(t, int64(199859), tx.Change.Value, "Incorrect change")
+	assert.Equal(t, "change", tx.Change.Address, "Incorrect change address")
+	assert.Equal(t, testChangeScript, tx.Tx.TxOut[1].PkScript, "Expected the change output last")
+
+	serialized, err := tx.Serialize()
+
+	assert.NoError(t, err, "Expected no error: valid transaction")
+	assert.Equal(t, "02000000013ba3edfd7a7b12b27ac72c3e67768f617fc81bc3888a51323a9fb8aa4b1e5e4a0100000000fdffffff02", hex.EncodeToString(serialized[:47]), "Incorrect serialization")
+}
+
+func TestBuild_Errors(t *testing.T) {
+	var utxos = []UTXO{{TxID: testTxID, Vout: 0, Value: 10000, ScriptPubKey: testP2WPKHScript}}
+	var change = Output{ScriptPubKey: testChangeScript}
+
+	var _, err = Build(utxos, []Output{{ScriptPubKey: testP2WPKHScript, Value: 20000}}, 1, change)
+
+	assert.Equal(t, ErrInsufficientFunds, err, "Expected error: insufficient funds")
+
+	_, err = Build(utxos, []Output{{ScriptPubKey: testP2WPKHScript, Value: 100}}, 1, change)
+
+	assert.Equal(t, ErrDustOutput, err, "Expected error: dust output")
+
+	_, err = Build(utxos, []Output{{ScriptPubKey: testP2WPKHScript, Value: 5000}}, 0, change)
+
+	assert.Equal(t, ErrInvalidFeeRate, err, "Expected error: zero fee rate")
+
+	_, err = Build([]UTXO{{TxID: "00", Value: 10000, ScriptPubKey: testP2WPKHScript}}, []Output{{ScriptPubKey: testP2WPKHScript, Value: 5000}}, 1, change)
+
+	assert.Equal(t, ErrInvalidTxID, err, "Expected error: short txid")
+
+	_, err = Build(nil, []Output{{ScriptPubKey: testP2WPKHScript, Value: 5000}}, 1, change)
+
+	assert.Equal(t, ErrNoUTXOs, err, "Expected error: no UTXO")
+}
+
+func TestBuild_DuplicateUTXO(t *testing.T) {
+	var utxos = []UTXO{
+		{TxID: testTxID, Vout: 0, Value: 60000, ScriptPubKey: testP2WPKHScript},
+		{TxID: testTxID, Vout: 0, Value: 60000, ScriptPubKey: testP2WPKHScript},
+	}
+	var outputs = []Output{{ScriptPubKey: testP2WPKHScript, Value: 100000}}
+
+	var _, err = Build(utxos, outputs, 1, Output{ScriptPubKey: testChangeScript})
+
+	assert.Equal(t, ErrDuplicateUTXO, err, "Expected error: outpoint spent twice")
+
+	// the same txid in upper case hex
+	utxos[1].TxID = strings.ToUpper(testTxID)
+	_, err = Build(utxos, outputs, 1, Output{ScriptPubKey: testChangeScript})
+
+	assert.Equal(t, ErrDuplicateUTXO, err, "Expected error: outpoint spent twice")
+
+	utxos[1].Vout = 1
+	_, err = Build(utxos, outputs, 1, Output{ScriptPubKey: testChangeScript})
+
+	assert.NoError(t, err, "Expected no error: distinct outpoints")
+}
+
+func TestBuild_ValueTooLarge(t *testing.T) {
+	var utxos = []UTXO{{TxID: testTxID, Vout: 0, Value: 100000, ScriptPubKey: testP2WPKHScript}}
+	var change = Output{ScriptPubKey: testChangeScript}
+
+	// two outputs overflowing int64 once summed
+	var outputs = []Output{
+		{ScriptPubKey: testP2WPKHScript, Value: 9000000000000000000},
+		{ScriptPubKey: testP2WPKHScript, Value: 9000000000000000000},
+	}
+	var _, err = Build(utxos, outputs, 1, change)
+
+	assert.Equal(t, ErrValueTooLarge, err, "Expected error: output above the supply")
+
+	// each output within the supply, their total above it
+	outputs = []Output{
+		{ScriptPubKey: testP2WPKHScript, Value: btcutil.MaxSatoshi},
+		{ScriptPubKey: testP2WPKHScript, Value: 1000},
+	}
+	_, err = Build(utxos, outputs, 1, change)
+
+	assert.Equal(t, ErrValueTooLarge, err, "Expected error: output total above the supply")
+
+	outputs = []Output{{ScriptPubKey: testP2WPKHScript, Value: 50000}}
+	utxos[0].Value = btcutil.MaxSatoshi + 1
+	_, err = Build(utxos, outputs, 1, change)
+
+	assert.Equal(t, ErrValueTooLarge, err, "Expected error: UTXO above the supply")
+
+	utxos[0].Value = btcutil.MaxSatoshi
+	utxos = append(utxos, UTXO{TxID: testTxID, Vout: 1, Value: 1000, ScriptPubKey: testP2WPKHScript})
+	_, err = Build(utxos, outputs, 1, change)
+
+	assert.Equal(t, ErrValueTooLarge, err, "Expected error: UTXO total above the supply")
+}
+
+func TestBuild_P2SHP2WSH(t *testing.T) {
+	// 2-of-3 compressed keys witness script nested in P2SH
+	var witnessScript = mustDecodeHex("522103a882d414e478039cd5b52a92ffb13dd5e6bd4515497439dffd691a0f12af957521036ce31db9bdd543e72fe3039a1f1c047dab87037c36a669ff90e28da1848f640d210311ffd36c70776538d079fbae117dc38effafb33304af83ce4894589747aee1ef53ae")
+	var utxos = []UTXO{{TxID: testTxID, Vout: 0, Value: 500000, ScriptPubKey: mustDecodeHex("a9149339a9bab2bfcd7755ee8058d926969555e5787987"), WitnessScript: witnessScript}}
+	var outputs = []Output{{ScriptPubKey: testP2WPKHScript, Value: 100000}}
+
+	var tx, err = Build(utxos, outputs, 1, Output{ScriptPubKey: testChangeScript})
+
+	assert.NoError(t, err, "Expected no error: matching witness script")
+
+	// overhead, P2SH-P2WSH input, payment and change outputs
+	assert.Equal(t, VirtualSize(4*(8+1+1)+2+76*4+1+1+2*73+1+105+2*4*31), tx.VirtualSize, "Incorrect vsize")
+
+	utxos[0].ScriptPubKey = mustDecodeHex("a914b472a266d0bd89c13706a4132ccfb16f7c3b9fcb87")
+	_, err = Build(utxos, outputs, 1, Output{ScriptPubKey: testChangeScript})
+
+	assert.Equal(t, ErrWitnessScriptMismatch, err, "Expected error: witness script of another P2SH")
+}
+
+func TestBuild_P2SH(t *testing.T) {
+	// 2-of-3 compressed keys redeem script
+	var redeemScript = mustDecodeHex("522103a882d414e478039cd5b52a92ffb13dd5e6bd4515497439dffd691a0f12af957521036ce31db9bdd543e72fe3039a1f1c047dab87037c36a669ff90e28da1848f640d210311ffd36c70776538d079fbae117dc38effafb33304af83ce4894589747aee1ef53ae")
+	var scriptPubKey = append(append([]byte{0xa9, 0x14}, btcutil.Hash160(redeemScript)...), 0x87)
+	var utxos = []UTXO{{TxID: testTxID, Vout: 0, Value: 500000, ScriptPubKey: scriptPubKey}}
+	var outputs = []Output{{ScriptPubKey: testP2WPKHScript, Value: 100000}}
+
+	var _, err = Build(utxos, outputs, 1, Output{ScriptPubKey: testChangeScript})
+
+	assert.Equal(t, ErrMissingRedeemScript, err, "Expected error: P2SH without redeem script")
+
+	utxos[0].RedeemScript = redeemScript
+	tx, err := Build(utxos, outputs, 1, Output{ScriptPubKey: testChangeScript})
+
+	assert.NoError(t, err, "Expected no error: matching redeem script")
+
+	// legacy overhead, P2SH multisig input with its 254 bytes scriptSig, payment and change outputs
+	assert.Equal(t, int64(8+1+1+36+3+254+4+2*31), tx.VirtualSize, "Incorrect vsize")
+
+	// P2WPKH program nested in P2SH
+	utxos[0].RedeemScript = testP2WPKHScript
+	_, err = Build(utxos, outputs, 1, Output{ScriptPubKey: testChangeScript})
+
+	assert.Equal(t, ErrRedeemScriptMismatch, err, "Expected error: redeem script of another P2SH")
+
+	utxos[0].ScriptPubKey = append(append([]byte{0xa9, 0x14}, btcutil.Hash160(testP2WPKHScript)...), 0x87)
+	tx, err = Build(utxos, outputs, 1, Output{ScriptPubKey: testChangeScript})
+
+	assert.NoError(t, err, "Expected no error: P2SH-P2WPKH redeem script")
+
+	// overhead, P2SH-P2WPKH input, payment and change outputs
+	assert.Equal(t, VirtualSize(4*(8+1+1)+2+4*(36+1+23+4)+1+1+72+1+33+2*4*31), tx.VirtualSize, "Incorrect vsize")
+}
@@ -0,0 +1,12 @@
+package request
+
+type AccountDescriptors struct {
+	Seed []byte `json:"seed"`
+	// Mnemonic and optional Passphrase, used instead of Seed
+	Mnemonic   string `json:"mnemonic"`
+	Passphrase string `json:"passphrase"`
+	// Path account path, e.g. m/84'/0'/0'
+	Path string `json:"path"`
+	// Network mainnet, testnet3, signet or regtest, server default when empty
+	Network string `json:"network"`
+}
@@ -0,0 +1,9 @@
+package request
+
+type AccountXPub struct {
+	Seed []byte `json:"seed"`
+	// Path account path, e.g. m/84'/0'/0'
+	Path string `json:"path"`
+	// Network mainnet, testnet3, signet or regtest, server default when empty
+	Network string `json:"network"`
+}
@@ -0,0 +1,19 @@
+package request
+
+type AddressRange struct {
+	// Seed and Path (account path, e.g. m/84'/0'/0') derive from a seed
+	Seed []byte `json:"seed"`
+	Path string `json:"path"`
+	// XPub account extended public key, used instead of Seed and Path
+	XPub string `json:"xpub"`
+	// Purpose of the XPub account, see WatchOnly
+	Purpose uint32 `json:"purpose"`
+	// Chain receive or change
+	Chain string `json:"chain"`
+	Start uint32 `json:"start"`
+	Count uint32 `json:"count"`
+	// Network mainnet, testnet3, signet or regtest, server default when empty
+	Network string `json:"network"`
+	// LegacyUncompressed legacy uncompressed key addresses, see HDSegWit
+	LegacyUncompressed bool `json:"legacy_uncompressed"`
+}
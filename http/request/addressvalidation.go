@@ -0,0 +1,7 @@
+package request
+
+type AddressValidation struct {
+	Address string `json:"address"`
+	// Network optional, the address must belong to it when given
+	Network string `json:"network"`
+}
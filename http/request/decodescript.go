@@ -0,0 +1,6 @@
+package request
+
+type DecodeScript struct {
+	// Script multisig redeem or witness script in hex
+	Script string `json:"script"`
+}
@@ -0,0 +1,13 @@
+package request
+
+type DeriveKey struct {
+	// Seed derive at an absolute path, relative paths start at the master key
+	Seed []byte `json:"seed"`
+	// XPub extended public key, used instead of Seed with a non hardened relative path
+	XPub string `json:"xpub"`
+	Path string `json:"path"`
+	// Strict enforce the BIP44 style m/purpose'/coin_type'/account'/change/index validation
+	Strict bool `json:"strict"`
+	// Network mainnet, testnet3, signet or regtest, server default when empty
+	Network string `json:"network"`
+}
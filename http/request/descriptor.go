@@ -0,0 +1,11 @@
+package request
+
+type DeriveDescriptor struct {
+	// Descriptor output descriptor, its checksum is verified when present
+	Descriptor string `json:"descriptor"`
+	Start      uint32 `json:"start"`
+	// Count number of addresses, 1 when empty
+	Count uint32 `json:"count"`
+	// Network network of raw public key descriptors, the extended key version decides when empty
+	Network string `json:"network"`
+}
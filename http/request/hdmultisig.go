@@ -0,0 +1,24 @@
+package request
+
+type Cosigner struct {
+	// XPub cosigner extended public key, xpub/tpub or SLIP-132 Ypub/Zpub (Upub/Vpub)
+	XPub string `json:"xpub"`
+	// Fingerprint and Path optional key origin, master fingerprint in hex and path to the key (e.g. m/48'/0'/0'/2')
+	Fingerprint string `json:"fingerprint"`
+	Path        string `json:"path"`
+}
+
+type HDMultiSig struct {
+	M         int        `json:"m"`
+	Cosigners []Cosigner `json:"cosigners"`
+	// ScriptType p2sh, p2sh-p2wsh or p2wsh, follows the BIP45/BIP48 key origins when empty
+	ScriptType string `json:"script_type"`
+	// CosignerIndex BIP45 cosigner_index derived before chain/index
+	CosignerIndex *uint32 `json:"cosigner_index"`
+	// Chain receive or change
+	Chain string `json:"chain"`
+	Start uint32 `json:"start"`
+	Count uint32 `json:"count"`
+	// Network mainnet, testnet3, signet or regtest, follows the key versions when empty
+	Network string `json:"network"`
+}
@@ -2,5 +2,16 @@ package request
 
 type HDSegWit struct {
 	Seed []byte `json:"seed"`
-	Path string `json:"path"`
+	// Mnemonic and optional Passphrase, used instead of Seed
+	Mnemonic   string `json:"mnemonic"`
+	Passphrase string `json:"passphrase"`
+	Path       string `json:"path"`
+	// Network mainnet, testnet3, signet or regtest, server default when empty
+	Network string `json:"network"`
+	// Include extra fields of the response: path, public_key, public_key_hash, script_pubkey,
+	// addresses, fingerprints or all
+	Include []string `json:"include"`
+	// LegacyUncompressed derive BIP44/49/84 addresses from uncompressed public keys, for wallets
+	// created before compressed keys were used
+	LegacyUncompressed bool `json:"legacy_uncompressed"`
 }
@@ -0,0 +1,19 @@
+package request
+
+type ExportKey struct {
+	Seed []byte `json:"seed"`
+	// Mnemonic and optional Passphrase, used instead of Seed
+	Mnemonic   string `json:"mnemonic"`
+	Passphrase string `json:"passphrase"`
+	Path       string `json:"path"`
+	// Compressed serialize the public key compressed, as the WIF flag and the address do, compressed
+	// unless legacy uncompressed keys are configured when empty
+	Compressed *bool  `json:"compressed"`
+	Network    string `json:"network"`
+}
+
+type ImportKey struct {
+	WIF string `json:"wif"`
+	// Network network of the addresses, the WIF version decides when empty
+	Network string `json:"network"`
+}
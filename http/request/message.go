@@ -0,0 +1,20 @@
+package request
+
+type SignMessage struct {
+	Message string `json:"message"`
+	// Path BIP44, BIP84 or BIP86 path of the signing key, its address type tells the signature format
+	Path string `json:"path"`
+	Seed []byte `json:"seed"`
+	// Mnemonic and optional Passphrase, used instead of Seed
+	Mnemonic   string `json:"mnemonic"`
+	Passphrase string `json:"passphrase"`
+	Network    string `json:"network"`
+	// LegacyUncompressed sign for the legacy uncompressed key address, see HDSegWit
+	LegacyUncompressed bool `json:"legacy_uncompressed"`
+}
+
+type VerifyMessage struct {
+	Address   string `json:"address"`
+	Message   string `json:"message"`
+	Signature string `json:"signature"`
+}
@@ -0,0 +1,6 @@
+package request
+
+type MnemonicSeed struct {
+	Mnemonic   string `json:"mnemonic"`
+	Passphrase string `json:"passphrase"`
+}
@@ -0,0 +1,7 @@
+package request
+
+type MnemonicValidation struct {
+	Mnemonic string `json:"mnemonic"`
+	// Language BIP39 wordlist, detected from the words when empty
+	Language string `json:"language"`
+}
@@ -4,4 +4,8 @@ type MultiSig struct {
 	N          int      `json:"n"`
 	M          int      `json:"m"`
 	PublicKeys []string `json:"public_keys"`
+	// ScriptType p2sh (default), p2sh-p2wsh or p2wsh
+	ScriptType string `json:"script_type"`
+	// SortKeys BIP67 key sorting, on by default for p2sh-p2wsh and p2wsh
+	SortKeys *bool `json:"sort_keys"`
 }
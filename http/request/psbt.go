@@ -0,0 +1,47 @@
+package request
+
+// KeyDerivation BIP32 origin of a multisig cosigner public key
+type KeyDerivation struct {
+	PublicKey string `json:"public_key"`
+	// Fingerprint master key fingerprint in hex
+	Fingerprint string `json:"fingerprint"`
+	Path        string `json:"path"`
+}
+
+type PSBTUTXO struct {
+	UTXO
+	// PrevTx previous transaction in hex, needed by legacy P2PKH and P2SH inputs
+	PrevTx string `json:"prev_tx"`
+	// Cosigners derivations of the other cosigner keys of a multisig UTXO
+	Cosigners []KeyDerivation `json:"cosigners"`
+}
+
+type CreatePSBT struct {
+	// Seed, or Mnemonic and Passphrase, derive the change address and the input derivations
+	Seed       []byte     `json:"seed"`
+	Mnemonic   string     `json:"mnemonic"`
+	Passphrase string     `json:"passphrase"`
+	UTXOs      []PSBTUTXO `json:"utxos"`
+	Outputs    []TxOutput `json:"outputs"`
+	// FeeRate sat/vB
+	FeeRate    float64 `json:"fee_rate"`
+	ChangePath string  `json:"change_path"`
+	Network    string  `json:"network"`
+}
+
+type SignPSBT struct {
+	// PSBT base64 encoded
+	PSBT       string `json:"psbt"`
+	Seed       []byte `json:"seed"`
+	Mnemonic   string `json:"mnemonic"`
+	Passphrase string `json:"passphrase"`
+	Network    string `json:"network"`
+}
+
+type CombinePSBT struct {
+	PSBTs []string `json:"psbts"`
+}
+
+type PSBT struct {
+	PSBT string `json:"psbt"`
+}
@@ -0,0 +1,19 @@
+package request
+
+type PrevOut struct {
+	Value        int64  `json:"value"`
+	ScriptPubKey string `json:"script_pubkey"`
+	// Path derivation path of the key owning the output
+	Path string `json:"path"`
+}
+
+type SignTransaction struct {
+	// Hex unsigned transaction
+	Hex string `json:"hex"`
+	// PrevOuts outputs spent, in input order
+	PrevOuts   []PrevOut `json:"prevouts"`
+	Seed       []byte    `json:"seed"`
+	Mnemonic   string    `json:"mnemonic"`
+	Passphrase string    `json:"passphrase"`
+	Network    string    `json:"network"`
+}
@@ -0,0 +1,33 @@
+package request
+
+type UTXO struct {
+	TxID         string `json:"txid"`
+	Vout         uint32 `json:"vout"`
+	Value        int64  `json:"value"`
+	ScriptPubKey string `json:"script_pubkey"`
+	// WitnessScript multisig witness script in hex, P2WSH and P2SH-P2WSH only
+	WitnessScript string `json:"witness_script"`
+	// RedeemScript redeem script in hex, P2SH only, a P2WPKH program or a legacy multisig script
+	RedeemScript string `json:"redeem_script"`
+	// Path optional derivation path of the key owning the output
+	Path string `json:"path"`
+}
+
+type TxOutput struct {
+	Address string `json:"address"`
+	Value   int64  `json:"value"`
+}
+
+type UnsignedTransaction struct {
+	// Seed, or Mnemonic and Passphrase, derive the change address
+	Seed       []byte     `json:"seed"`
+	Mnemonic   string     `json:"mnemonic"`
+	Passphrase string     `json:"passphrase"`
+	UTXOs      []UTXO     `json:"utxos"`
+	Outputs    []TxOutput `json:"outputs"`
+	// FeeRate sat/vB
+	FeeRate float64 `json:"fee_rate"`
+	// ChangePath derivation path of the change address, e.g. m/84'/0'/0'/1/0
+	ChangePath string `json:"change_path"`
+	Network    string `json:"network"`
+}
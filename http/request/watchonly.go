@@ -0,0 +1,15 @@
+package request
+
+type WatchOnly struct {
+	// XPub account extended public key (xpub/ypub/zpub or tpub/upub/vpub)
+	XPub string `json:"xpub"`
+	// Path relative change/index path, e.g. 0/5
+	Path string `json:"path"`
+	// Purpose 44, 49, 84 or 86 of the account, inferred from the key version when zero,
+	// needed for BIP86 accounts whose xpub/tpub version is the BIP44 one
+	Purpose uint32 `json:"purpose"`
+	// Network mainnet, testnet3, signet or regtest, inferred from the key version when empty
+	Network string `json:"network"`
+	// LegacyUncompressed legacy uncompressed key addresses, see HDSegWit
+	LegacyUncompressed bool `json:"legacy_uncompressed"`
+}
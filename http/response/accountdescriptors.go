@@ -0,0 +1,12 @@
+package response
+
+type AccountDescriptors struct {
+	Path              string `json:"path"`
+	MasterFingerprint string `json:"master_fingerprint"`
+	// Receive and Change watch-only descriptors with xpub keys
+	Receive string `json:"receive"`
+	Change  string `json:"change"`
+	// ReceivePrivate and ChangePrivate spending descriptors with xprv keys, only given when key export is enabled
+	ReceivePrivate string `json:"receive_private,omitempty"`
+	ChangePrivate  string `json:"change_private,omitempty"`
+}
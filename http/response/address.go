@@ -3,3 +3,15 @@ package response
 type Address struct {
 	Address string `json:"address"`
 }
+
+type DerivedAddress struct {
+	Path      string `json:"path"`
+	Address   string `json:"address"`
+	PublicKey string `json:"public_key"`
+}
+
+type AddressRange struct {
+	Addresses []DerivedAddress `json:"addresses"`
+	// NextStart start index of the following page
+	NextStart uint32 `json:"next_start"`
+}
@@ -0,0 +1,14 @@
+package response
+
+type AddressValidation struct {
+	Valid   bool   `json:"valid"`
+	Address string `json:"address"`
+	// Networks every network the address belongs to, test networks share prefixes
+	Networks       []string `json:"networks,omitempty"`
+	Type           string   `json:"type,omitempty"`
+	WitnessVersion *int     `json:"witness_version,omitempty"`
+	WitnessProgram string   `json:"witness_program,omitempty"`
+	ScriptPubKey   string   `json:"script_pubkey,omitempty"`
+	// Error why the address is invalid
+	Error string `json:"error,omitempty"`
+}
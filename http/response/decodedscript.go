@@ -0,0 +1,22 @@
+package response
+
+type DecodedPublicKey struct {
+	PublicKey  string `json:"public_key"`
+	Compressed bool   `json:"compressed"`
+}
+
+type ScriptAddresses struct {
+	P2SH string `json:"p2sh"`
+	// P2SHP2WSH and P2WSH only when every public key is compressed
+	P2SHP2WSH string `json:"p2sh_p2wsh,omitempty"`
+	P2WSH     string `json:"p2wsh,omitempty"`
+}
+
+type DecodedScript struct {
+	M          int                `json:"m"`
+	N          int                `json:"n"`
+	PublicKeys []DecodedPublicKey `json:"public_keys"`
+	ASM        string             `json:"asm"`
+	// Addresses by network name
+	Addresses map[string]ScriptAddresses `json:"addresses"`
+}
@@ -0,0 +1,10 @@
+package response
+
+type DerivedKey struct {
+	Path              string `json:"path"`
+	PublicKey         string `json:"public_key"`
+	ChainCode         string `json:"chain_code"`
+	Depth             byte   `json:"depth"`
+	ParentFingerprint string `json:"parent_fingerprint"`
+	ExtendedPublicKey string `json:"extended_public_key"`
+}
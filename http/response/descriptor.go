@@ -0,0 +1,21 @@
+package response
+
+type DescriptorAddress struct {
+	Index        uint32   `json:"index"`
+	Address      string   `json:"address"`
+	ScriptPubKey string   `json:"script_pubkey"`
+	PublicKeys   []string `json:"public_keys"`
+}
+
+type Descriptor struct {
+	// Descriptor descriptor with its checksum
+	Descriptor string `json:"descriptor"`
+	Type       string `json:"type"`
+	// ScriptType p2sh, p2sh-p2wsh or p2wsh of multisig descriptors
+	ScriptType string              `json:"script_type,omitempty"`
+	Ranged     bool                `json:"ranged"`
+	Network    string              `json:"network"`
+	Addresses  []DescriptorAddress `json:"addresses"`
+	// NextStart start index of the following page
+	NextStart uint32 `json:"next_start"`
+}
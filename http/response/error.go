@@ -6,6 +6,22 @@ const (
 	ErrInvalidPath = "INVALID_PATH"
 	ErrInvalidInput = "INVALID_INPUT"
 	ErrSeed = "INVALID_SEED"
+	ErrInvalidNetwork = "INVALID_NETWORK"
+	ErrInvalidExtendedKey = "INVALID_EXTENDED_KEY"
+	ErrInvalidMnemonic = "INVALID_MNEMONIC"
+	ErrInvalidScript = "INVALID_SCRIPT"
+	ErrNonStandardScript = "NON_STANDARD_SCRIPT"
+	ErrWitnessProgram = "WITNESS_PROGRAM"
+	ErrInvalidPublicKey = "INVALID_PUBLIC_KEY"
+	ErrInvalidAddress = "INVALID_ADDRESS"
+	ErrInsufficientFunds = "INSUFFICIENT_FUNDS"
+	ErrInvalidPSBT = "INVALID_PSBT"
+	ErrPSBTMismatch = "PSBT_MISMATCH"
+	ErrPSBTIncomplete = "PSBT_INCOMPLETE"
+	ErrInvalidDescriptor = "INVALID_DESCRIPTOR"
+	ErrInvalidWIF = "INVALID_WIF"
+	ErrKeyExportDisabled = "KEY_EXPORT_DISABLED"
+	ErrInvalidInclude = "INVALID_INCLUDE"
 	ErrInternal = "INTERNAL"
 )
 
@@ -25,6 +41,38 @@ func GetResponse(e responseError) ErrorResponse {
 		msg = "Invalid path"
 	case ErrSeed:
 		msg = "Invalid path"
+	case ErrInvalidNetwork:
+		msg = "Invalid network"
+	case ErrInvalidExtendedKey:
+		msg = "Invalid extended key"
+	case ErrInvalidMnemonic:
+		msg = "Invalid mnemonic"
+	case ErrInvalidScript:
+		msg = "Invalid script"
+	case ErrNonStandardScript:
+		msg = "Non standard multisig script"
+	case ErrWitnessProgram:
+		msg = "Witness program, decode the witness script"
+	case ErrInvalidPublicKey:
+		msg = "Invalid public key"
+	case ErrInvalidAddress:
+		msg = "Invalid address"
+	case ErrInsufficientFunds:
+		msg = "Insufficient funds"
+	case ErrInvalidPSBT:
+		msg = "Invalid PSBT"
+	case ErrPSBTMismatch:
+		msg = "PSBTs of different transactions"
+	case ErrPSBTIncomplete:
+		msg = "PSBT has inputs not finalized"
+	case ErrInvalidDescriptor:
+		msg = "Invalid descriptor"
+	case ErrInvalidWIF:
+		msg = "Invalid WIF private key"
+	case ErrKeyExportDisabled:
+		msg = "Private key export is disabled, see bitcoin.key_export"
+	case ErrInvalidInclude:
+		msg = "Invalid include, use path, public_key, public_key_hash, script_pubkey, addresses, fingerprints or all"
 	default:
 		msg = "Internal server error"
 	}
@@ -0,0 +1,7 @@
+package response
+
+type ExtendedKey struct {
+	Path              string `json:"path"`
+	ExtendedKey       string `json:"extended_key"`
+	MasterFingerprint string `json:"master_fingerprint"`
+}
@@ -0,0 +1,19 @@
+package response
+
+type HDMultiSigAddress struct {
+	Path    string `json:"path"`
+	Address string `json:"address"`
+	// PublicKeys cosigner child keys in BIP67 order
+	PublicKeys    []string `json:"public_keys"`
+	RedeemScript  string   `json:"redeem_script,omitempty"`
+	WitnessScript string   `json:"witness_script,omitempty"`
+}
+
+type HDMultiSig struct {
+	ScriptType string              `json:"script_type"`
+	Addresses  []HDMultiSigAddress `json:"addresses"`
+	// Descriptor ranged output descriptor of the chain
+	Descriptor string `json:"descriptor"`
+	// NextStart start index of the following page
+	NextStart uint32 `json:"next_start"`
+}
@@ -0,0 +1,20 @@
+package response
+
+type KeyAddresses struct {
+	P2PKH      string `json:"p2pkh"`
+	P2SHP2WPKH string `json:"p2sh_p2wpkh"`
+	P2WPKH     string `json:"p2wpkh"`
+	P2TR       string `json:"p2tr"`
+}
+
+type HDSegWit struct {
+	Address string `json:"address"`
+	// fields below are only given when requested by include
+	Path              string        `json:"path,omitempty"`
+	PublicKey         string        `json:"public_key,omitempty"`
+	PublicKeyHash     string        `json:"public_key_hash,omitempty"`
+	ScriptPubKey      string        `json:"script_pubkey,omitempty"`
+	Addresses         *KeyAddresses `json:"addresses,omitempty"`
+	ParentFingerprint string        `json:"parent_fingerprint,omitempty"`
+	MasterFingerprint string        `json:"master_fingerprint,omitempty"`
+}
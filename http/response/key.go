@@ -0,0 +1,22 @@
+package response
+
+type ExportedKey struct {
+	Path       string `json:"path"`
+	WIF        string `json:"wif"`
+	PrivateKey string `json:"private_key"`
+	PublicKey  string `json:"public_key"`
+	Compressed bool   `json:"compressed"`
+	// Address address of the path purpose under the public key serialization
+	Address string `json:"address"`
+}
+
+type ImportedKey struct {
+	Network    string `json:"network"`
+	PublicKey  string `json:"public_key"`
+	Compressed bool   `json:"compressed"`
+	P2PKH      string `json:"p2pkh"`
+	// P2SHP2WPKH and P2WPKH are left out for uncompressed keys
+	P2SHP2WPKH string `json:"p2sh_p2wpkh,omitempty"`
+	P2WPKH     string `json:"p2wpkh,omitempty"`
+	P2TR       string `json:"p2tr"`
+}
@@ -0,0 +1,18 @@
+package response
+
+type SignedMessage struct {
+	Address string `json:"address"`
+	Message string `json:"message"`
+	// Signature base64 signature
+	Signature string `json:"signature"`
+	// Format legacy or bip322
+	Format string `json:"format"`
+}
+
+type MessageVerification struct {
+	Valid   bool   `json:"valid"`
+	Address string `json:"address"`
+	Format  string `json:"format,omitempty"`
+	// Error why the signature does not verify
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,18 @@
+package response
+
+type UnknownWord struct {
+	Index       int      `json:"index"`
+	Word        string   `json:"word"`
+	Suggestions []string `json:"suggestions"`
+}
+
+type MnemonicValidation struct {
+	Valid          bool          `json:"valid"`
+	Language       string        `json:"language"`
+	WordCount      int           `json:"word_count"`
+	ValidWordCount bool          `json:"valid_word_count"`
+	UnknownWords   []UnknownWord `json:"unknown_words"`
+	ChecksumValid  bool          `json:"checksum_valid"`
+	// ChecksumWords valid last words, given 11, 14, 17, 20 or 23 known words
+	ChecksumWords []string `json:"checksum_words,omitempty"`
+}
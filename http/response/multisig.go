@@ -0,0 +1,15 @@
+package response
+
+type MultiSig struct {
+	Address string `json:"address"`
+	// PublicKeys keys in the order used by the script
+	PublicKeys []string `json:"public_keys"`
+	SortedKeys bool     `json:"sorted_keys"`
+	// RedeemScript script revealed by P2SH and P2SH-P2WSH spending inputs
+	RedeemScript string `json:"redeem_script,omitempty"`
+	// WitnessScript script revealed by P2WSH and P2SH-P2WSH witnesses
+	WitnessScript string `json:"witness_script,omitempty"`
+	ScriptHash    string `json:"script_hash"`
+	ASM           string `json:"asm"`
+	Descriptor    string `json:"descriptor"`
+}
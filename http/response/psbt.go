@@ -0,0 +1,25 @@
+package response
+
+type PSBTInput struct {
+	TxID       string `json:"txid"`
+	Vout       uint32 `json:"vout"`
+	Signatures int    `json:"signatures"`
+	Finalized  bool   `json:"finalized"`
+}
+
+type PSBT struct {
+	// PSBT base64 encoded
+	PSBT   string      `json:"psbt"`
+	Inputs []PSBTInput `json:"inputs"`
+	// Fee omitted while an input UTXO is unknown
+	Fee int64 `json:"fee,omitempty"`
+	// Signed signatures added by the request
+	Signed   int  `json:"signed,omitempty"`
+	Complete bool `json:"complete"`
+}
+
+type SignedTransaction struct {
+	Hex         string `json:"hex"`
+	TxID        string `json:"txid"`
+	VirtualSize int64  `json:"vsize"`
+}
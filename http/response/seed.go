@@ -0,0 +1,7 @@
+package response
+
+type Seed struct {
+	Seed              []byte `json:"seed"`
+	SeedHex           string `json:"seed_hex"`
+	MasterFingerprint string `json:"master_fingerprint"`
+}
@@ -0,0 +1,24 @@
+package response
+
+type TxInput struct {
+	TxID  string `json:"txid"`
+	Vout  uint32 `json:"vout"`
+	Value int64  `json:"value"`
+	Path  string `json:"path,omitempty"`
+}
+
+type UnsignedTransaction struct {
+	Hex    string    `json:"hex"`
+	Inputs []TxInput `json:"inputs"`
+	// ChangeAddress and ChangeValue empty when the excess went to the fee
+	ChangeAddress string `json:"change_address,omitempty"`
+	ChangeValue   int64  `json:"change_value,omitempty"`
+	// Algorithm coin selection used, branch_and_bound or largest_first
+	Algorithm   string  `json:"algorithm"`
+	InputTotal  int64   `json:"input_total"`
+	OutputTotal int64   `json:"output_total"`
+	Fee         int64   `json:"fee"`
+	FeeRate     float64 `json:"fee_rate"`
+	// VirtualSize estimated signed transaction size in vbytes
+	VirtualSize int64 `json:"vsize"`
+}
@@ -0,0 +1,53 @@
+package walletapi
+
+import (
+	"btcwalletapi/cryto/psbt"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// CombinePSBT handle PSBT combination request, merging the signatures of every cosigner
+func (api *BTCWalletAPI) CombinePSBT(res http.ResponseWriter, req *http.Request) {
+	res.Header().Set("Content-Type", "application/json")
+	var reqBody request.CombinePSBT
+	json.NewDecoder(req.Body).Decode(&reqBody)
+
+	if len(reqBody.PSBTs) == 0 {
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidInput))
+		return
+	}
+
+	var packets = make([]*psbt.Packet, len(reqBody.PSBTs))
+	for i, encoded := range reqBody.PSBTs {
+		packet, err := psbt.ParseBase64(encoded)
+		if err != nil {
+			log.Println(err)
+			res.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidPSBT))
+			return
+		}
+		packets[i] = packet
+	}
+
+	combined, err := psbt.Combine(packets)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrPSBTMismatch))
+		return
+	}
+
+	result, err := psbtResponse(combined)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+		return
+	}
+
+	json.NewEncoder(res).Encode(result)
+}
@@ -0,0 +1,53 @@
+package walletapi
+
+import (
+	"bytes"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRoute_CombinePSBT_ReturnNormal(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	var unsigned = createTestPSBT(t, 300000).PSBT
+	params := request.CombinePSBT{
+		PSBTs: []string{unsigned, signTestPSBT(t, unsigned).PSBT},
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CombinePSBT(w, r)
+
+	var res response.PSBT
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	assert.Equal(t, 1, res.Inputs[0].Signatures, "Expected the signature merged")
+}
+
+func TestRoute_CombinePSBT_ReturnMismatchError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := request.CombinePSBT{
+		PSBTs: []string{createTestPSBT(t, 300000).PSBT, createTestPSBT(t, 200000).PSBT},
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CombinePSBT(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedCode = "PSBT_MISMATCH"
+
+	assert.Equal(t, expectedCode, res.Code, "Expected error error: different transactions")
+}
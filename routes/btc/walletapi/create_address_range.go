@@ -0,0 +1,134 @@
+package walletapi
+
+import (
+	"btcwalletapi/cryto/segwit"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// CreateAddressRange handle batch address request of an account chain, from a seed or an extended public key
+func (api *BTCWalletAPI) CreateAddressRange(res http.ResponseWriter, req *http.Request) {
+	res.Header().Set("Content-Type", "application/json")
+	var reqBody request.AddressRange
+	json.NewDecoder(req.Body).Decode(&reqBody)
+
+	// validate range
+	if reqBody.Count < 1 || reqBody.Count > api.maxRangeCount() {
+		log.Printf("count %d out of range [1, %d]", reqBody.Count, api.maxRangeCount())
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidInput))
+		return
+	}
+
+	chain, err := segwit.ParseChain(reqBody.Chain)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidInput))
+		return
+	}
+
+	var addresses []segwit.DerivedAddress
+	var network *chaincfg.Params
+	if reqBody.XPub != "" {
+		// resolve network, the key version decides when none is requested
+		if reqBody.Network != "" {
+			network, err = segwit.ParseNetwork(reqBody.Network)
+			if err != nil {
+				log.Println(err)
+				res.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidNetwork))
+				return
+			}
+		}
+
+		// resolve purpose, the key version decides when none is requested
+		var purpose segwit.Purpose
+		purpose, err = segwit.ParsePurpose(reqBody.Purpose)
+		if err != nil {
+			log.Println(err)
+			res.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidInput))
+			return
+		}
+
+		addresses, err = segwit.GetWatchOnlyAddressRange(reqBody.XPub, network, purpose, chain, reqBody.Start, reqBody.Count, api.uncompressedKeys(reqBody.LegacyUncompressed))
+	} else {
+		// resolve network, falling back to the server default
+		network, err = api.network(reqBody.Network)
+		if err != nil {
+			log.Println(err)
+			res.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidNetwork))
+			return
+		}
+
+		// decode account path
+		var accountPath []uint32
+		accountPath, err = segwit.ParseAccountPath(reqBody.Path, network)
+		if err != nil {
+			log.Println(err)
+			res.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidPath))
+			return
+		}
+
+		var km *segwit.KeyManager
+		km, err = segwit.NewKeyManager(reqBody.Seed, network)
+		if err != nil {
+			log.Println(err)
+			res.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(res).Encode(response.GetResponse(response.ErrSeed))
+			return
+		}
+		km.SetUncompressed(api.uncompressedKeys(reqBody.LegacyUncompressed))
+
+		addresses, err = km.GetAddressRange(
+			accountPath[0], accountPath[1], accountPath[2], chain,
+			reqBody.Start, reqBody.Count,
+		)
+	}
+	switch err {
+	case nil:
+	case segwit.ErrIndexOutOfRange, segwit.ErrPurposeMismatch:
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidInput))
+		return
+	case segwit.ErrNetworkMismatch:
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidNetwork))
+		return
+	case segwit.ErrInvalidExtendedKey, segwit.ErrUnsupportedKeyVersion, segwit.ErrNotPublicKey:
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidExtendedKey))
+		return
+	default:
+		log.Println(err)
+		res.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+		return
+	}
+
+	var result = response.AddressRange{
+		Addresses: make([]response.DerivedAddress, 0, len(addresses)),
+		NextStart: reqBody.Start + reqBody.Count,
+	}
+	for _, address := range addresses {
+		result.Addresses = append(result.Addresses, response.DerivedAddress{
+			Path:      address.Path,
+			Address:   address.Address,
+			PublicKey: hex.EncodeToString(address.PublicKey),
+		})
+	}
+
+	json.NewEncoder(res).Encode(result)
+}
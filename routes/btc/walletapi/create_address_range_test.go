@@ -0,0 +1,137 @@
+package walletapi
+
+import (
+	"bytes"
+	"btcwalletapi/http/response"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRoute_CreateAddressRange_ReturnNormal(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	seed, _ := hex.DecodeString("5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4")
+	params := struct {
+		Seed  []byte `json:"seed"`
+		Path  string `json:"path"`
+		Chain string `json:"chain"`
+		Start uint32 `json:"start"`
+		Count uint32 `json:"count"`
+	}{
+		Seed:  seed,
+		Path:  "m/86'/0'/0'",
+		Chain: "receive",
+		Start: 0,
+		Count: 2,
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreateAddressRange(w, r)
+
+	var res response.AddressRange
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	assert.Len(t, res.Addresses, 2, "Incorrect number of addresses")
+	assert.Equal(t, "m/86'/0'/0'/0/1", res.Addresses[1].Path, "Incorrect path")
+	assert.Equal(t, "bc1p4qhjn9zdvkux4e44uhx8tc55attvtyu358kutcqkudyccelu0was9fqzwh", res.Addresses[1].Address, "Incorrect address")
+}
+
+func TestRoute_CreateAddressRange_ReturnWatchOnly(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := struct {
+		XPub  string `json:"xpub"`
+		Chain string `json:"chain"`
+		Start uint32 `json:"start"`
+		Count uint32 `json:"count"`
+	}{
+		XPub:  "zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs",
+		Chain: "change",
+		Start: 5,
+		Count: 3,
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreateAddressRange(w, r)
+
+	var res response.AddressRange
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	assert.Len(t, res.Addresses, 3, "Incorrect number of addresses")
+	assert.Equal(t, "1/5", res.Addresses[0].Path, "Incorrect path")
+	assert.Equal(t, uint32(8), res.NextStart, "Incorrect next page start")
+}
+
+func TestRoute_CreateAddressRange_ReturnInvalidInputError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	seed, _ := hex.DecodeString("5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4")
+	params := struct {
+		Seed  []byte `json:"seed"`
+		Path  string `json:"path"`
+		Chain string `json:"chain"`
+		Count uint32 `json:"count"`
+	}{
+		Seed:  seed,
+		Path:  "m/84'/0'/0'",
+		Chain: "receive",
+		Count: defaultMaxRangeCount + 1,
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreateAddressRange(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedCode = "INVALID_INPUT"
+
+	assert.Equal(t, expectedCode, res.Code, "Expected error error: count above the limit")
+}
+
+func TestRoute_CreateAddressRange_ReturnIndexOutOfRangeError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	seed, _ := hex.DecodeString("5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4")
+	params := struct {
+		Seed  []byte `json:"seed"`
+		Path  string `json:"path"`
+		Chain string `json:"chain"`
+		Start uint32 `json:"start"`
+		Count uint32 `json:"count"`
+	}{
+		Seed:  seed,
+		Path:  "m/84'/0'/0'",
+		Chain: "receive",
+		Start: 2147483647,
+		Count: 2,
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreateAddressRange(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedCode = "INVALID_INPUT"
+
+	assert.Equal(t, 400, w.Code, "Expected bad request status")
+	assert.Equal(t, expectedCode, res.Code, "Expected error error: range reaches hardened indexes")
+}
@@ -0,0 +1,136 @@
+package walletapi
+
+import (
+	"btcwalletapi/cryto/descriptor"
+	"btcwalletapi/cryto/multisig"
+	"btcwalletapi/cryto/segwit"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// CreateHDMultiSigAddress handle batch multisig address request of cosigner extended public keys, following BIP45/BIP48
+func (api *BTCWalletAPI) CreateHDMultiSigAddress(res http.ResponseWriter, req *http.Request) {
+	res.Header().Set("Content-Type", "application/json")
+	var reqBody request.HDMultiSig
+	json.NewDecoder(req.Body).Decode(&reqBody)
+
+	// validate range
+	if reqBody.Count < 1 || reqBody.Count > api.maxRangeCount() {
+		log.Printf("count %d out of range [1, %d]", reqBody.Count, api.maxRangeCount())
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidInput))
+		return
+	}
+
+	chain, err := segwit.ParseChain(reqBody.Chain)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidInput))
+		return
+	}
+
+	// validate script type, the key origins decide when none is requested
+	var scriptType multisig.ScriptType
+	if reqBody.ScriptType != "" {
+		scriptType, err = multisig.ParseScriptType(reqBody.ScriptType)
+		if err != nil {
+			log.Println(err)
+			res.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidInput))
+			return
+		}
+	}
+
+	// resolve network, the key versions decide when none is requested
+	var network *chaincfg.Params
+	if reqBody.Network != "" {
+		network, err = segwit.ParseNetwork(reqBody.Network)
+		if err != nil {
+			log.Println(err)
+			res.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidNetwork))
+			return
+		}
+	}
+
+	var cosignerKeys = make([]multisig.CosignerKey, len(reqBody.Cosigners))
+	for i, cosigner := range reqBody.Cosigners {
+		cosignerKeys[i] = multisig.CosignerKey{XPub: cosigner.XPub, Fingerprint: cosigner.Fingerprint, Path: cosigner.Path}
+	}
+
+	// derive addresses
+	hd, err := multisig.NewHDMultisig(reqBody.M, cosignerKeys, scriptType, network, reqBody.CosignerIndex)
+	var addresses []*multisig.HDAddress
+	if err == nil {
+		addresses, err = hd.AddressRange(chain, reqBody.Start, reqBody.Count)
+	}
+	switch err {
+	case nil:
+	case segwit.ErrNetworkMismatch, multisig.ErrCosignerNetworkMixed:
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidNetwork))
+		return
+	case segwit.ErrInvalidExtendedKey, segwit.ErrUnsupportedKeyVersion, segwit.ErrNotPublicKey:
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidExtendedKey))
+		return
+	case segwit.ErrInvalidPath, segwit.ErrEmptyPath, segwit.ErrPathTooDeep, segwit.ErrInvalidComponent,
+		segwit.ErrComponentOutOfRange, multisig.ErrOriginDepthMismatch:
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidPath))
+		return
+	case multisig.ErrEmptyCosigners, multisig.ErrNRange, multisig.ErrMRange, multisig.ErrInvalidFingerprint,
+		multisig.ErrScriptTypeMismatch, multisig.ErrMissingCosignerIndex, multisig.ErrHardenedCosignerIndex,
+		multisig.ErrUncompressedWitnessKey, multisig.ErrScriptSize, segwit.ErrIndexOutOfRange:
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidInput))
+		return
+	default:
+		log.Println(err)
+		res.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+		return
+	}
+
+	outputDescriptor, err := descriptor.HDMultisig(hd, chain)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+		return
+	}
+
+	var result = response.HDMultiSig{
+		ScriptType: string(hd.ScriptType),
+		Addresses:  make([]response.HDMultiSigAddress, 0, len(addresses)),
+		Descriptor: outputDescriptor,
+		NextStart:  reqBody.Start + reqBody.Count,
+	}
+	for _, address := range addresses {
+		var publicKeys = make([]string, len(address.Script.PublicKeys))
+		for i, publicKey := range address.Script.PublicKeys {
+			publicKeys[i] = hex.EncodeToString(publicKey)
+		}
+
+		result.Addresses = append(result.Addresses, response.HDMultiSigAddress{
+			Path:          address.Path,
+			Address:       address.Script.Address,
+			PublicKeys:    publicKeys,
+			RedeemScript:  hex.EncodeToString(address.Script.RedeemScript()),
+			WitnessScript: hex.EncodeToString(address.Script.WitnessScript()),
+		})
+	}
+
+	json.NewEncoder(res).Encode(result)
+}
@@ -0,0 +1,98 @@
+package walletapi
+
+import (
+	"bytes"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var testCosigners = []request.Cosigner{
+	{XPub: "xpub6DkFAXWQ2dHxq2vatrt9qyA3bXYU4ToWQwCHbf5XB2mSTexcHZCeKS1VZYcPoBd5X8yVcbXFHJR9R8UCVpt82VX1VhR28mCyxUFL4r6KFrf", Fingerprint: "73c5da0a", Path: "m/48'/0'/0'/2'"},
+	{XPub: "xpub6DzhyrnFFYQ1HimDiM388xHnDiRPNdZJFBmmxge3Y1WWcHLtMJLfRuhRHqnQCPbTj3fGKTuKFLHzzwpJkp5Dtc3UtLKZKaVZe1yqMBXd6Vk", Fingerprint: "73c5da0a", Path: "m/48'/0'/1'/2'"},
+	{XPub: "xpub6EGx8sPr9FxPPE1rbZazhqWwpMXA3Hf5DYKtZbL7c4BSddzmQktp96UaTvecEkoCZysuaj79GMCFZYT1KKk7Ph2M3Kf5g8B82KZ8TZ9SKQR", Fingerprint: "73c5da0a", Path: "m/48'/0'/2'/2'"},
+}
+
+func TestRoute_CreateHDMultiSigAddress_ReturnNormal(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := request.HDMultiSig{
+		M:         2,
+		Cosigners: testCosigners,
+		Chain:     "receive",
+		Start:     0,
+		Count:     2,
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreateHDMultiSigAddress(w, r)
+
+	var res response.HDMultiSig
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	assert.Equal(t, "p2wsh", res.ScriptType, "Expected P2WSH from the BIP48 key origins")
+	assert.Len(t, res.Addresses, 2, "Incorrect number of addresses")
+	assert.Equal(t, "0/1", res.Addresses[1].Path, "Incorrect path")
+	assert.Equal(t, "bc1qg8fpeqrl9uf3w5vawye5s235xylqhyxd7gjs4hq78crn6sm5w3asar4472", res.Addresses[1].Address, "Incorrect address")
+	assert.True(t, strings.HasPrefix(res.Descriptor, "wsh(sortedmulti(2,[73c5da0a/48'/0'/0'/2']"), "Incorrect descriptor")
+	assert.Equal(t, uint32(2), res.NextStart, "Incorrect next start")
+}
+
+func TestRoute_CreateHDMultiSigAddress_ReturnInvalidInputError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := request.HDMultiSig{
+		M:          2,
+		Cosigners:  testCosigners,
+		ScriptType: "p2sh",
+		Chain:      "receive",
+		Count:      1,
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreateHDMultiSigAddress(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedCode = "INVALID_INPUT"
+
+	assert.Equal(t, expectedCode, res.Code, "Expected error error: script type against BIP48 origins")
+}
+
+func TestRoute_CreateHDMultiSigAddress_ReturnInvalidNetworkError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := request.HDMultiSig{
+		M:         2,
+		Cosigners: testCosigners,
+		Chain:     "receive",
+		Count:     1,
+		Network:   "testnet3",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreateHDMultiSigAddress(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedCode = "INVALID_NETWORK"
+
+	assert.Equal(t, expectedCode, res.Code, "Expected error error: mainnet keys on testnet")
+}
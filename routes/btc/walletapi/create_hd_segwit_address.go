@@ -4,19 +4,81 @@ import (
 	"btcwalletapi/cryto/segwit"
 	"btcwalletapi/http/request"
 	"btcwalletapi/http/response"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
+	"strings"
 )
 
+// includeAll include value standing for every optional field
+const includeAll = "all"
+
+// includeFields optional fields of the HD segwit address response
+var includeFields = []string{"path", "public_key", "public_key_hash", "script_pubkey", "addresses", "fingerprints"}
+
+var errInvalidInclude = errors.New("unknown include field")
+
+// parseInclude give the set of requested optional fields
+func parseInclude(include []string) (map[string]bool, error) {
+	var result = make(map[string]bool, len(includeFields))
+	for _, name := range include {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == includeAll {
+			for _, field := range includeFields {
+				result[field] = true
+			}
+			continue
+		}
+		var known bool
+		for _, field := range includeFields {
+			known = known || field == name
+		}
+		if !known {
+			return nil, errInvalidInclude
+		}
+		result[name] = true
+	}
+
+	return result, nil
+}
+
 // CreateHDSegWitAddress handle Hierarchical Deterministic (HD) Segregated Witness (SegWit) bitcoin address request
 func (api *BTCWalletAPI) CreateHDSegWitAddress(res http.ResponseWriter, req *http.Request) {
 	res.Header().Set("Content-Type", "application/json")
 	var reqBody request.HDSegWit
 	json.NewDecoder(req.Body).Decode(&reqBody)
 
+	// resolve network, falling back to the server default
+	var network, err = api.network(reqBody.Network)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidNetwork))
+		return
+	}
+
+	// resolve seed, from the mnemonic when no raw seed is given
+	hdSeed, err := seed(reqBody.Seed, reqBody.Mnemonic, reqBody.Passphrase)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidMnemonic))
+		return
+	}
+
+	km, err := segwit.NewKeyManager(hdSeed, network)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrSeed))
+		return
+	}
+	km.SetUncompressed(api.uncompressedKeys(reqBody.LegacyUncompressed))
+
 	// decode path
-	var derivationPath, err = segwit.ParseDerivationPath(reqBody.Path)
+	derivationPath, err := segwit.ParseDerivationPath(reqBody.Path, network)
 	if err != nil {
 		log.Println(err)
 		res.WriteHeader(http.StatusBadRequest)
@@ -24,11 +86,21 @@ func (api *BTCWalletAPI) CreateHDSegWitAddress(res http.ResponseWriter, req *htt
 		return
 	}
 
+	// resolve the optional fields
+	included, err := parseInclude(reqBody.Include)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidInclude))
+		return
+	}
+	if len(included) > 0 {
+		api.createHDSegWitKeyBundle(res, km, derivationPath, included)
+		return
+	}
+
 	// create address
-	address, err := segwit.GetAddress(
-		reqBody.Seed,
-		derivationPath[0], derivationPath[1], derivationPath[2], derivationPath[3], derivationPath[4],
-	)
+	address, err := km.GetAddress(derivationPath[0], derivationPath[1], derivationPath[2], derivationPath[3], derivationPath[4])
 	if err != nil {
 		log.Println(err)
 		res.WriteHeader(http.StatusInternalServerError)
@@ -40,3 +112,42 @@ func (api *BTCWalletAPI) CreateHDSegWitAddress(res http.ResponseWriter, req *htt
 		Address: address,
 	})
 }
+
+// createHDSegWitKeyBundle answer an HD segwit address request with the included fields of its key bundle
+func (api *BTCWalletAPI) createHDSegWitKeyBundle(res http.ResponseWriter, km *segwit.KeyManager, derivationPath []uint32, included map[string]bool) {
+	bundle, err := km.GetKeyBundle(derivationPath[0], derivationPath[1], derivationPath[2], derivationPath[3], derivationPath[4])
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+		return
+	}
+
+	var result = response.HDSegWit{Address: bundle.Address}
+	if included["path"] {
+		result.Path = bundle.Path
+	}
+	if included["public_key"] {
+		result.PublicKey = hex.EncodeToString(bundle.PublicKey)
+	}
+	if included["public_key_hash"] {
+		result.PublicKeyHash = hex.EncodeToString(bundle.PublicKeyHash)
+	}
+	if included["script_pubkey"] {
+		result.ScriptPubKey = hex.EncodeToString(bundle.ScriptPubKey)
+	}
+	if included["addresses"] {
+		result.Addresses = &response.KeyAddresses{
+			P2PKH:      bundle.P2PKH,
+			P2SHP2WPKH: bundle.P2SHP2WPKH,
+			P2WPKH:     bundle.P2WPKH,
+			P2TR:       bundle.P2TR,
+		}
+	}
+	if included["fingerprints"] {
+		result.ParentFingerprint = hex.EncodeToString(bundle.ParentFingerprint)
+		result.MasterFingerprint = hex.EncodeToString(bundle.MasterFingerprint)
+	}
+
+	json.NewEncoder(res).Encode(result)
+}
@@ -2,6 +2,7 @@ package walletapi
 
 import (
 	"bytes"
+	"btcwalletapi/http/request"
 	"btcwalletapi/http/response"
 	"encoding/json"
 	"github.com/stretchr/testify/assert"
@@ -30,11 +31,35 @@ func TestRoute_CreateHDSegWitAddress_ReturnNormal(t *testing.T) {
 
 	assert.NoError(t, err, "Expected no error: valid response struct")
 
-	var expectedAddress = "bc1qjslpmfrrmhu4hwmsd7an9n3hctys6452xln2ek"
+	var expectedAddress = "bc1q84kvjqueplk6h80x89j4mjujvq3svfwcz8pavd"
 
 	assert.Equal(t, expectedAddress, res.Address, "Incorrect address")
 }
 
+func TestRoute_CreateHDSegWitAddress_ReturnLegacyAddress(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := request.HDSegWit{
+		Seed:               []byte{244, 184, 4, 62, 59, 59, 77, 11, 158, 60, 124, 218, 129, 214, 134, 140, 51, 26, 174, 204, 128, 85, 93, 199, 178, 208, 237, 206, 107, 115, 234, 80, 169, 29, 103, 88, 111, 116, 97, 205, 70, 202, 204, 238, 110, 36, 10, 89, 138, 154, 170, 48, 99, 205, 217, 190, 198, 90, 61, 36, 211, 170, 85, 27},
+		Path:               "m/84'/0'/0'/0/0",
+		LegacyUncompressed: true,
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreateHDSegWitAddress(w, r)
+
+	var res response.Address
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedAddress = "bc1qjslpmfrrmhu4hwmsd7an9n3hctys6452xln2ek"
+
+	assert.Equal(t, expectedAddress, res.Address, "Incorrect legacy uncompressed key address")
+}
+
 func TestRoute_CreateHDSegWitAddress_ReturnInvalidPathError(t *testing.T) {
 	var api = BTCWalletAPI{}
 
@@ -60,3 +85,160 @@ func TestRoute_CreateHDSegWitAddress_ReturnInvalidPathError(t *testing.T) {
 
 	assert.Equal(t, expectedCode, res.Code, "Expected error error: invalid path")
 }
+
+func TestRoute_CreateHDSegWitAddress_ReturnTestnetAddress(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := struct {
+		Seed    []byte `json:"seed"`
+		Path    string `json:"path"`
+		Network string `json:"network"`
+	}{
+		Seed:    []byte{244, 184, 4, 62, 59, 59, 77, 11, 158, 60, 124, 218, 129, 214, 134, 140, 51, 26, 174, 204, 128, 85, 93, 199, 178, 208, 237, 206, 107, 115, 234, 80, 169, 29, 103, 88, 111, 116, 97, 205, 70, 202, 204, 238, 110, 36, 10, 89, 138, 154, 170, 48, 99, 205, 217, 190, 198, 90, 61, 36, 211, 170, 85, 27},
+		Path:    "m/84'/1'/0'/0/0",
+		Network: "testnet3",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreateHDSegWitAddress(w, r)
+
+	var res response.Address
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	assert.Equal(t, "tb1q", res.Address[:4], "Expected testnet address")
+}
+
+func TestRoute_CreateHDSegWitAddress_ReturnInvalidNetworkError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := struct {
+		Seed    []byte `json:"seed"`
+		Path    string `json:"path"`
+		Network string `json:"network"`
+	}{
+		Seed:    []byte{244, 184, 4, 62, 59, 59, 77, 11, 158, 60, 124, 218, 129, 214, 134, 140, 51, 26, 174, 204, 128, 85, 93, 199, 178, 208, 237, 206, 107, 115, 234, 80, 169, 29, 103, 88, 111, 116, 97, 205, 70, 202, 204, 238, 110, 36, 10, 89, 138, 154, 170, 48, 99, 205, 217, 190, 198, 90, 61, 36, 211, 170, 85, 27},
+		Path:    "m/84'/0'/0'/0/0",
+		Network: "dogecoin",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreateHDSegWitAddress(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedCode = "INVALID_NETWORK"
+
+	assert.Equal(t, expectedCode, res.Code, "Expected error error: invalid network")
+}
+
+func TestRoute_CreateHDSegWitAddress_ReturnTaprootAddress(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := struct {
+		Seed []byte `json:"seed"`
+		Path string `json:"path"`
+	}{
+		Seed: []byte{94, 176, 11, 189, 220, 240, 105, 8, 72, 137, 168, 171, 145, 85, 86, 129, 101, 245, 196, 83, 204, 184, 94, 112, 129, 26, 174, 214, 246, 218, 95, 193, 154, 90, 196, 11, 56, 156, 211, 112, 208, 134, 32, 109, 236, 138, 166, 196, 61, 174, 166, 105, 15, 32, 173, 61, 141, 72, 178, 210, 206, 158, 56, 228},
+		Path: "m/86'/0'/0'/0/0",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreateHDSegWitAddress(w, r)
+
+	var res response.Address
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedAddress = "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr"
+
+	assert.Equal(t, expectedAddress, res.Address, "Incorrect address")
+}
+
+func TestRoute_CreateHDSegWitAddress_ReturnMnemonicAddress(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := struct {
+		Mnemonic string `json:"mnemonic"`
+		Path     string `json:"path"`
+	}{
+		Mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		Path:     "m/86'/0'/0'/0/0",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreateHDSegWitAddress(w, r)
+
+	var res response.Address
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedAddress = "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr"
+
+	assert.Equal(t, expectedAddress, res.Address, "Incorrect address")
+}
+
+func TestRoute_CreateHDSegWitAddress_ReturnKeyBundle(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := request.HDSegWit{
+		Mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		Path:     "m/86'/0'/0'/0/0",
+		Include:  []string{"all"},
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreateHDSegWitAddress(w, r)
+
+	var res response.HDSegWit
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	// BIP86 test vector
+	assert.Equal(t, "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr", res.Address, "Incorrect address")
+	assert.Equal(t, "m/86'/0'/0'/0/0", res.Path, "Incorrect path")
+	assert.Equal(t, "03cc8a4bc64d897bddc5fbc2f670f7a8ba0b386779106cf1223c6fc5d7cd6fc115", res.PublicKey, "Incorrect public key")
+	assert.Equal(t, "5120a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c", res.ScriptPubKey, "Incorrect scriptPubKey")
+	assert.Equal(t, "73c5da0a", res.MasterFingerprint, "Incorrect master fingerprint")
+	assert.Equal(t, res.Address, res.Addresses.P2TR, "Expected the address among the key addresses")
+}
+
+func TestRoute_CreateHDSegWitAddress_ReturnInvalidIncludeError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := request.HDSegWit{
+		Mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		Path:     "m/86'/0'/0'/0/0",
+		Include:  []string{"private_key"},
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreateHDSegWitAddress(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedCode = "INVALID_INCLUDE"
+
+	assert.Equal(t, expectedCode, res.Code, "Expected error error: unknown include field")
+}
@@ -6,14 +6,37 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
+	"strconv"
 )
 
 // CreateMnemonic handle random mnemonic words request, following BIP39 standard
-func (api *BTCWalletAPI) CreateMnemonic(res http.ResponseWriter, _ *http.Request) {
+func (api *BTCWalletAPI) CreateMnemonic(res http.ResponseWriter, req *http.Request) {
 	res.Header().Set("Content-Type", "application/json")
+
+	// decode word count and language, 24 english words by default
+	var wordCount = mnemonic.DefaultWordCount
+	if words := req.URL.Query().Get("words"); words != "" {
+		var err error
+		wordCount, err = strconv.Atoi(words)
+		if err != nil {
+			log.Println(err)
+			res.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidInput))
+			return
+		}
+	}
+	var language = req.URL.Query().Get("language")
+
 	// create mnemonic
-	var mnmnic, err = mnemonic.GetMnemonic()
-	if err != nil {
+	var mnmnic, err = mnemonic.GetMnemonic(wordCount, language)
+	switch err {
+	case nil:
+	case mnemonic.ErrInvalidWordCount, mnemonic.ErrUnsupportedLanguage:
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidInput))
+		return
+	default:
 		log.Println(err)
 		res.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
@@ -23,4 +46,3 @@ func (api *BTCWalletAPI) CreateMnemonic(res http.ResponseWriter, _ *http.Request
 		Mnemonic: mnmnic,
 	})
 }
-
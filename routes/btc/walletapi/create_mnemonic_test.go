@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/tyler-smith/go-bip39"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -25,4 +26,37 @@ func TestRoute_CreateMnemonic_ReturnNormal(t *testing.T) {
 	_, err = bip39.EntropyFromMnemonic(res.Mnemonic)
 
 	assert.NoError(t, err, "Expected no error: valid mnemonic")
-}
\ No newline at end of file
+}
+
+func TestRoute_CreateMnemonic_ReturnWordCountAndLanguage(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	var r = httptest.NewRequest("GET", "/?words=12&language=spanish", nil)
+	var w = httptest.NewRecorder()
+
+	api.CreateMnemonic(w, r)
+
+	var res response.Mnemonic
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	assert.Len(t, strings.Fields(res.Mnemonic), 12, "Incorrect word count")
+}
+
+func TestRoute_CreateMnemonic_ReturnInvalidInputError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	var r = httptest.NewRequest("GET", "/?words=13", nil)
+	var w = httptest.NewRecorder()
+
+	api.CreateMnemonic(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedCode = "INVALID_INPUT"
+
+	assert.Equal(t, expectedCode, res.Code, "Expected error error: invalid word count")
+}
@@ -1,23 +1,50 @@
 package walletapi
 
 import (
+	"btcwalletapi/cryto/descriptor"
 	"btcwalletapi/cryto/multisig"
 	"btcwalletapi/http/request"
 	"btcwalletapi/http/response"
+	"encoding/hex"
 	"encoding/json"
 	"log"
 	"net/http"
 )
 
-// CreateMultiSigP2SHAddress handle n-out-of-m Multisignature (multi-sig) Pay-To-Script-Hash (P2SH) bitcoin address
+// CreateMultiSigP2SHAddress handle n-out-of-m Multisignature (multi-sig) Pay-To-Script-Hash (P2SH) bitcoin address,
+// or its native (P2WSH) and nested (P2SH-P2WSH) segwit forms
 func (api *BTCWalletAPI) CreateMultiSigP2SHAddress(res http.ResponseWriter, req *http.Request) {
 	res.Header().Set("Content-Type", "application/json")
 
 	var reqBody request.MultiSig
 	json.NewDecoder(req.Body).Decode(&reqBody)
 
+	// network of the server
+	network, err := api.network("")
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidNetwork))
+		return
+	}
+
+	// validate script type
+	scriptType, err := multisig.ParseScriptType(reqBody.ScriptType)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidInput))
+		return
+	}
+
+	// BIP67 sorting defaults on for segwit script types, legacy P2SH keeps the caller order
+	var sortKeys = scriptType != multisig.ScriptTypeP2SH
+	if reqBody.SortKeys != nil {
+		sortKeys = *reqBody.SortKeys
+	}
+
 	// create address
-	address, _, err := multisig.GenerateAddress(reqBody.M, reqBody.N, reqBody.PublicKeys)
+	script, err := multisig.GenerateScriptAddress(reqBody.M, reqBody.N, reqBody.PublicKeys, scriptType, sortKeys, network)
 	if err != nil {
 		log.Println(err)
 		res.WriteHeader(http.StatusBadRequest)
@@ -25,7 +52,42 @@ func (api *BTCWalletAPI) CreateMultiSigP2SHAddress(res http.ResponseWriter, req
 		return
 	}
 
-	json.NewEncoder(res).Encode(response.Address{
-		Address: address,
+	// describe the script for cosigners
+	scriptHash, err := script.ScriptHash()
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+		return
+	}
+	asm, err := script.ASM()
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+		return
+	}
+	outputDescriptor, err := descriptor.Multisig(script)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+		return
+	}
+
+	var publicKeys = make([]string, len(script.PublicKeys))
+	for i, publicKey := range script.PublicKeys {
+		publicKeys[i] = hex.EncodeToString(publicKey)
+	}
+
+	json.NewEncoder(res).Encode(response.MultiSig{
+		Address:       script.Address,
+		PublicKeys:    publicKeys,
+		SortedKeys:    script.Sorted,
+		RedeemScript:  hex.EncodeToString(script.RedeemScript()),
+		WitnessScript: hex.EncodeToString(script.WitnessScript()),
+		ScriptHash:    hex.EncodeToString(scriptHash),
+		ASM:           asm,
+		Descriptor:    outputDescriptor,
 	})
 }
@@ -26,7 +26,7 @@ func TestRoute_CreateMultiSigP2SHAddress_ReturnNormal(t *testing.T) {
 
 	api.CreateMultiSigP2SHAddress(w, r)
 
-	var res response.Address
+	var res response.MultiSig
 	var err = json.NewDecoder(w.Body).Decode(&res)
 
 	assert.NoError(t, err, "Expected no error: valid response struct")
@@ -36,6 +36,70 @@ func TestRoute_CreateMultiSigP2SHAddress_ReturnNormal(t *testing.T) {
 	assert.Equal(t, expectedAddress, res.Address, "Incorrect address")
 }
 
+func TestRoute_CreateMultiSigP2SHAddress_Compressed_ReturnNormal(t *testing.T) {
+	var api = BTCWalletAPI{}
+	params := struct {
+		M          int      `json:"m"`
+		N          int      `json:"n"`
+		PublicKeys []string `json:"public_keys"`
+	}{
+		M: 2,
+		N: 3,
+		PublicKeys: []string{"03a882d414e478039cd5b52a92ffb13dd5e6bd4515497439dffd691a0f12af9575","036ce31db9bdd543e72fe3039a1f1c047dab87037c36a669ff90e28da1848f640d","0311ffd36c70776538d079fbae117dc38effafb33304af83ce4894589747aee1ef"},
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreateMultiSigP2SHAddress(w, r)
+
+	var res response.MultiSig
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedAddress = "3MsXykid1v9i3FWP4Fbj8vVLKrjPbBQCbP"
+
+	assert.Equal(t, expectedAddress, res.Address, "Incorrect address")
+}
+
+func TestRoute_CreateMultiSigP2SHAddress_P2WSH_ReturnNormal(t *testing.T) {
+	var api = BTCWalletAPI{}
+	params := struct {
+		M          int      `json:"m"`
+		N          int      `json:"n"`
+		PublicKeys []string `json:"public_keys"`
+		ScriptType string   `json:"script_type"`
+	}{
+		M: 2,
+		N: 3,
+		PublicKeys: []string{"03a882d414e478039cd5b52a92ffb13dd5e6bd4515497439dffd691a0f12af9575","036ce31db9bdd543e72fe3039a1f1c047dab87037c36a669ff90e28da1848f640d","0311ffd36c70776538d079fbae117dc38effafb33304af83ce4894589747aee1ef"},
+		ScriptType: "p2wsh",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreateMultiSigP2SHAddress(w, r)
+
+	var res response.MultiSig
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedAddress = "bc1q99043vxxfrecllzdmamcmr5g3epxrn6wkavfnm2aa63gzmnqh8aqylrez4"
+	var expectedKeys = []string{"0311ffd36c70776538d079fbae117dc38effafb33304af83ce4894589747aee1ef","036ce31db9bdd543e72fe3039a1f1c047dab87037c36a669ff90e28da1848f640d","03a882d414e478039cd5b52a92ffb13dd5e6bd4515497439dffd691a0f12af9575"}
+
+	assert.Equal(t, expectedAddress, res.Address, "Incorrect address")
+	assert.Equal(t, expectedKeys, res.PublicKeys, "Expected keys sorted by default")
+	assert.True(t, res.SortedKeys, "Expected keys sorted by default")
+	assert.Empty(t, res.RedeemScript, "Expected no redeem script for P2WSH")
+	assert.Equal(t, "5221"+expectedKeys[0]+"21"+expectedKeys[1]+"21"+expectedKeys[2]+"53ae", res.WitnessScript, "Incorrect witness script")
+	assert.Len(t, res.ScriptHash, 64, "Incorrect script hash length")
+	assert.Equal(t, "2 "+expectedKeys[0]+" "+expectedKeys[1]+" "+expectedKeys[2]+" 3 OP_CHECKMULTISIG", res.ASM, "Incorrect ASM")
+	assert.Contains(t, res.Descriptor, "wsh(sortedmulti(2,"+expectedKeys[0]+","+expectedKeys[1]+","+expectedKeys[2]+"))#", "Incorrect descriptor")
+}
+
 func TestRoute_CreateMultiSigP2SHAddress_ReturnInvalidInputError(t *testing.T) {
 	var api = BTCWalletAPI{}
 	params := struct {
@@ -62,3 +126,31 @@ func TestRoute_CreateMultiSigP2SHAddress_ReturnInvalidInputError(t *testing.T) {
 
 	assert.Equal(t, expectedCode, res.Code, "Expected error error: invalid input")
 }
+
+func TestRoute_CreateMultiSigP2SHAddress_SortUncompressed_ReturnInvalidInputError(t *testing.T) {
+	var api = BTCWalletAPI{}
+	var sortKeys = true
+	params := struct {
+		M          int      `json:"m"`
+		N          int      `json:"n"`
+		PublicKeys []string `json:"public_keys"`
+		SortKeys   *bool    `json:"sort_keys"`
+	}{
+		M:          2,
+		N:          3,
+		PublicKeys: []string{"04a882d414e478039cd5b52a92ffb13dd5e6bd4515497439dffd691a0f12af9575fa349b5694ed3155b136f09e63975a1700c9f4d4df849323dac06cf3bd6458cd", "046ce31db9bdd543e72fe3039a1f1c047dab87037c36a669ff90e28da1848f640de68c2fe913d363a51154a0c62d7adea1b822d05035077418267b1a1379790187", "0411ffd36c70776538d079fbae117dc38effafb33304af83ce4894589747aee1ef992f63280567f52f5ba870678b4ab4ff6c8ea600bd217870a8b4f1f09f3a8e83"},
+		SortKeys:   &sortKeys,
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreateMultiSigP2SHAddress(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	assert.Equal(t, 400, w.Code, "Expected status 400")
+	assert.Equal(t, "INVALID_INPUT", res.Code, "Expected error: BIP67 sorting of uncompressed keys")
+}
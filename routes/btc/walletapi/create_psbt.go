@@ -0,0 +1,279 @@
+package walletapi
+
+import (
+	"btcwalletapi/cryto/psbt"
+	"btcwalletapi/cryto/segwit"
+	"btcwalletapi/cryto/transaction"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/btcsuite/btcd/wire"
+)
+
+var (
+	errRelativePath       = errors.New("derivation path must be absolute")
+	errInvalidFingerprint = errors.New("fingerprint must be 4 bytes in hex")
+)
+
+// CreatePSBT handle PSBT creation request, selecting the UTXOs as an unsigned transaction does
+// and recording the BIP32 derivations of the seed and cosigner keys
+func (api *BTCWalletAPI) CreatePSBT(res http.ResponseWriter, req *http.Request) {
+	res.Header().Set("Content-Type", "application/json")
+	var reqBody request.CreatePSBT
+	json.NewDecoder(req.Body).Decode(&reqBody)
+
+	// resolve network, falling back to the server default
+	var network, err = api.network(reqBody.Network)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidNetwork))
+		return
+	}
+
+	// resolve seed, from the mnemonic when no raw seed is given
+	hdSeed, err := seed(reqBody.Seed, reqBody.Mnemonic, reqBody.Passphrase)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidMnemonic))
+		return
+	}
+	km, err := segwit.NewKeyManager(hdSeed, network)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrSeed))
+		return
+	}
+
+	// derive change address
+	changePath, err := segwit.ParseDerivationPath(reqBody.ChangePath, network)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidPath))
+		return
+	}
+	changeAddress, err := segwit.GetAddress(
+		hdSeed,
+		network,
+		changePath[0], changePath[1], changePath[2], changePath[3], changePath[4],
+	)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrSeed))
+		return
+	}
+	changeInfo, err := segwit.DecodeAddress(changeAddress)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+		return
+	}
+
+	// decode outputs
+	outputs, err := decodeOutputs(reqBody.Outputs, network)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidAddress))
+		return
+	}
+
+	// decode UTXOs, keeping their PSBT metadata by outpoint as coin selection reorders them
+	var reqUTXOs = make([]request.UTXO, len(reqBody.UTXOs))
+	var metadata = make(map[string]request.PSBTUTXO, len(reqBody.UTXOs))
+	for i, utxo := range reqBody.UTXOs {
+		reqUTXOs[i] = utxo.UTXO
+		if _, ok := metadata[outPointKey(utxo.TxID, utxo.Vout)]; ok {
+			log.Println(transaction.ErrDuplicateUTXO)
+			res.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidInput))
+			return
+		}
+		metadata[outPointKey(utxo.TxID, utxo.Vout)] = utxo
+	}
+	utxos, err := decodeUTXOs(reqUTXOs)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidInput))
+		return
+	}
+
+	// select coins and build
+	tx, err := transaction.Build(utxos, outputs, reqBody.FeeRate, transaction.Output{Address: changeAddress, ScriptPubKey: changeInfo.ScriptPubKey})
+	switch err {
+	case nil:
+	case transaction.ErrInsufficientFunds:
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInsufficientFunds))
+		return
+	default:
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidInput))
+		return
+	}
+
+	packet, err := psbt.New(tx)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+		return
+	}
+
+	// previous transactions, then the seed and cosigner derivations of every input
+	for i, input := range tx.Inputs {
+		utxo := metadata[outPointKey(input.TxID, input.Vout)]
+
+		if utxo.PrevTx != "" {
+			if err := setPreviousTransaction(packet, i, utxo.PrevTx); err != nil {
+				log.Println(err)
+				res.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidInput))
+				return
+			}
+		}
+
+		if utxo.Path != "" {
+			path, err := parseAbsolutePath(utxo.Path)
+			if err == nil {
+				err = packet.AddInputDerivation(i, km, path)
+			}
+			if err != nil {
+				log.Println(err)
+				res.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidPath))
+				return
+			}
+		}
+
+		for _, cosigner := range utxo.Cosigners {
+			derivation, err := parseKeyDerivation(cosigner)
+			if err != nil {
+				log.Println(err)
+				res.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidInput))
+				return
+			}
+			if err := packet.AddInputCosigner(i, *derivation); err != nil {
+				log.Println(err)
+				res.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidPublicKey))
+				return
+			}
+		}
+	}
+
+	// the change output is always the last one
+	if tx.Change != nil {
+		if err := packet.AddOutputDerivation(len(tx.Tx.TxOut)-1, km, changePath); err != nil {
+			log.Println(err)
+			res.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+			return
+		}
+	}
+
+	result, err := psbtResponse(packet)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+		return
+	}
+
+	json.NewEncoder(res).Encode(result)
+}
+
+// outPointKey identify a UTXO by its outpoint
+func outPointKey(txID string, vout uint32) string {
+	return fmt.Sprintf("%s:%d", txID, vout)
+}
+
+// setPreviousTransaction decode a hex serialized previous transaction and attach it to an input
+func setPreviousTransaction(packet *psbt.Packet, index int, prevTxHex string) error {
+	serialized, err := hex.DecodeString(prevTxHex)
+	if err != nil {
+		return err
+	}
+
+	var prevTx wire.MsgTx
+	if err := prevTx.Deserialize(bytes.NewReader(serialized)); err != nil {
+		return err
+	}
+
+	return packet.SetNonWitnessUTXO(index, &prevTx)
+}
+
+// parseAbsolutePath parse an absolute derivation path of any depth
+func parseAbsolutePath(path string) ([]uint32, error) {
+	components, relative, err := segwit.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	if relative {
+		return nil, errRelativePath
+	}
+
+	return components, nil
+}
+
+// parseKeyDerivation decode a cosigner public key with its master fingerprint and absolute path
+func parseKeyDerivation(derivation request.KeyDerivation) (*psbt.Derivation, error) {
+	publicKey, err := hex.DecodeString(derivation.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	fingerprint, err := hex.DecodeString(derivation.Fingerprint)
+	if err != nil || len(fingerprint) != 4 {
+		return nil, errInvalidFingerprint
+	}
+	path, err := parseAbsolutePath(derivation.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &psbt.Derivation{PublicKey: publicKey, Fingerprint: fingerprint, Path: path}, nil
+}
+
+// psbtResponse describe a PSBT, the fee is left out while an input UTXO is unknown
+func psbtResponse(packet *psbt.Packet) (response.PSBT, error) {
+	encoded, err := packet.B64Encode()
+	if err != nil {
+		return response.PSBT{}, err
+	}
+
+	var result = response.PSBT{
+		PSBT:     encoded,
+		Inputs:   make([]response.PSBTInput, 0, len(packet.Inputs)),
+		Complete: packet.Complete(),
+	}
+	for i, input := range packet.Inputs {
+		outPoint := packet.UnsignedTx.TxIn[i].PreviousOutPoint
+		result.Inputs = append(result.Inputs, response.PSBTInput{
+			TxID:       outPoint.Hash.String(),
+			Vout:       outPoint.Index,
+			Signatures: len(input.PartialSigs),
+			Finalized:  input.Finalized(),
+		})
+	}
+	if fee, err := packet.Fee(); err == nil {
+		result.Fee = fee
+	}
+
+	return result, nil
+}
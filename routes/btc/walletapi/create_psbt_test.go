@@ -0,0 +1,93 @@
+package walletapi
+
+import (
+	"bytes"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+// testPSBTRequest spend the BIP84 first receive output of the abandon ... about mnemonic
+func testPSBTRequest(value int64, path string) request.CreatePSBT {
+	return request.CreatePSBT{
+		Mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		UTXOs: []request.PSBTUTXO{
+			{UTXO: request.UTXO{TxID: "4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b", Vout: 1, Value: 500000, ScriptPubKey: "0014c0cebcd6c3d3ca8c75dc5ec62ebe55330ef910e2", Path: path}},
+		},
+		Outputs:    []request.TxOutput{{Address: "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", Value: value}},
+		FeeRate:    1,
+		ChangePath: "m/84'/0'/0'/1/0",
+	}
+}
+
+// createTestPSBT create a PSBT through the API
+func createTestPSBT(t *testing.T, value int64) response.PSBT {
+	var api = BTCWalletAPI{}
+
+	paramsByte, _ := json.Marshal(testPSBTRequest(value, "m/84'/0'/0'/0/0"))
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreatePSBT(w, r)
+
+	var res response.PSBT
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	return res
+}
+
+func TestRoute_CreatePSBT_ReturnNormal(t *testing.T) {
+	var res = createTestPSBT(t, 300000)
+
+	assert.NotEmpty(t, res.PSBT, "Expected a PSBT")
+	assert.Equal(t, "cHNidP8B", res.PSBT[:8], "Incorrect PSBT magic")
+	assert.Len(t, res.Inputs, 1, "Incorrect input count")
+	assert.Equal(t, 0, res.Inputs[0].Signatures, "Expected no signature")
+	assert.Equal(t, int64(141), res.Fee, "Incorrect fee")
+	assert.False(t, res.Complete, "Expected an unsigned PSBT")
+}
+
+func TestRoute_CreatePSBT_ReturnInvalidPathError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	// the key at m/84'/0'/0'/0/1 does not own the UTXO
+	paramsByte, _ := json.Marshal(testPSBTRequest(300000, "m/84'/0'/0'/0/1"))
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreatePSBT(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedCode = "INVALID_PATH"
+
+	assert.Equal(t, expectedCode, res.Code, "Expected error error: key not owning the UTXO")
+}
+
+func TestRoute_CreatePSBT_ReturnDuplicateUTXOError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := testPSBTRequest(300000, "m/84'/0'/0'/0/0")
+	params.UTXOs = append(params.UTXOs, params.UTXOs[0])
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreatePSBT(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedCode = "INVALID_INPUT"
+
+	assert.Equal(t, expectedCode, res.Code, "Expected error error: UTXO given twice")
+}
@@ -0,0 +1,42 @@
+package walletapi
+
+import (
+	"btcwalletapi/cryto/mnemonic"
+	"btcwalletapi/cryto/segwit"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// CreateSeed handle mnemonic to seed request, following BIP39 standard
+func (api *BTCWalletAPI) CreateSeed(res http.ResponseWriter, req *http.Request) {
+	res.Header().Set("Content-Type", "application/json")
+	var reqBody request.MnemonicSeed
+	json.NewDecoder(req.Body).Decode(&reqBody)
+
+	// create seed
+	var seed, err = mnemonic.ToSeed(reqBody.Mnemonic, reqBody.Passphrase)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidMnemonic))
+		return
+	}
+
+	fingerprint, err := segwit.GetMasterFingerprint(seed)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+		return
+	}
+
+	json.NewEncoder(res).Encode(response.Seed{
+		Seed:              seed,
+		SeedHex:           hex.EncodeToString(seed),
+		MasterFingerprint: fingerprint,
+	})
+}
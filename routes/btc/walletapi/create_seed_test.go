@@ -0,0 +1,60 @@
+package walletapi
+
+import (
+	"bytes"
+	"btcwalletapi/http/response"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRoute_CreateSeed_ReturnNormal(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := struct {
+		Mnemonic string `json:"mnemonic"`
+	}{
+		Mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreateSeed(w, r)
+
+	var res response.Seed
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedSeed = "5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4"
+
+	assert.Equal(t, expectedSeed, res.SeedHex, "Incorrect seed")
+	assert.Len(t, res.Seed, 64, "Incorrect seed length")
+	assert.Equal(t, "73c5da0a", res.MasterFingerprint, "Incorrect master fingerprint")
+}
+
+func TestRoute_CreateSeed_ReturnInvalidMnemonicError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := struct {
+		Mnemonic string `json:"mnemonic"`
+	}{
+		Mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreateSeed(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedCode = "INVALID_MNEMONIC"
+
+	assert.Equal(t, expectedCode, res.Code, "Expected error error: invalid mnemonic")
+}
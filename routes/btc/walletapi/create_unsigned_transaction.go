@@ -0,0 +1,180 @@
+package walletapi
+
+import (
+	"btcwalletapi/cryto/segwit"
+	"btcwalletapi/cryto/transaction"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// CreateUnsignedTransaction handle unsigned transaction request, selecting the UTXOs paying the outputs and the fee
+func (api *BTCWalletAPI) CreateUnsignedTransaction(res http.ResponseWriter, req *http.Request) {
+	res.Header().Set("Content-Type", "application/json")
+	var reqBody request.UnsignedTransaction
+	json.NewDecoder(req.Body).Decode(&reqBody)
+
+	// resolve network, falling back to the server default
+	var network, err = api.network(reqBody.Network)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidNetwork))
+		return
+	}
+
+	// resolve seed, from the mnemonic when no raw seed is given
+	hdSeed, err := seed(reqBody.Seed, reqBody.Mnemonic, reqBody.Passphrase)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidMnemonic))
+		return
+	}
+
+	// derive change address
+	changePath, err := segwit.ParseDerivationPath(reqBody.ChangePath, network)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidPath))
+		return
+	}
+	changeAddress, err := segwit.GetAddress(
+		hdSeed,
+		network,
+		changePath[0], changePath[1], changePath[2], changePath[3], changePath[4],
+	)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+		return
+	}
+	changeInfo, err := segwit.DecodeAddress(changeAddress)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+		return
+	}
+
+	// decode outputs
+	outputs, err := decodeOutputs(reqBody.Outputs, network)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidAddress))
+		return
+	}
+
+	// decode UTXOs
+	utxos, err := decodeUTXOs(reqBody.UTXOs)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidInput))
+		return
+	}
+
+	// select coins and build
+	tx, err := transaction.Build(utxos, outputs, reqBody.FeeRate, transaction.Output{Address: changeAddress, ScriptPubKey: changeInfo.ScriptPubKey})
+	switch err {
+	case nil:
+	case transaction.ErrInsufficientFunds:
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInsufficientFunds))
+		return
+	default:
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidInput))
+		return
+	}
+
+	serialized, err := tx.Serialize()
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+		return
+	}
+
+	var result = response.UnsignedTransaction{
+		Hex:         hex.EncodeToString(serialized),
+		Inputs:      make([]response.TxInput, 0, len(tx.Inputs)),
+		Algorithm:   tx.Algorithm,
+		InputTotal:  tx.InputTotal,
+		OutputTotal: tx.OutputTotal,
+		Fee:         tx.Fee,
+		FeeRate:     tx.FeeRate,
+		VirtualSize: tx.VirtualSize,
+	}
+	for _, input := range tx.Inputs {
+		result.Inputs = append(result.Inputs, response.TxInput{
+			TxID:  input.TxID,
+			Vout:  input.Vout,
+			Value: input.Value,
+			Path:  input.Path,
+		})
+	}
+	if tx.Change != nil {
+		result.ChangeAddress = tx.Change.Address
+		result.ChangeValue = tx.Change.Value
+	}
+
+	json.NewEncoder(res).Encode(result)
+}
+
+// decodeOutputs decode the output addresses to their scriptPubKey, every address must belong to the network
+func decodeOutputs(reqOutputs []request.TxOutput, network *chaincfg.Params) ([]transaction.Output, error) {
+	var outputs = make([]transaction.Output, len(reqOutputs))
+	for i, output := range reqOutputs {
+		info, err := segwit.DecodeAddress(output.Address)
+		if err != nil {
+			return nil, err
+		}
+		if !info.BelongsTo(network) {
+			return nil, errAddressNetwork
+		}
+		outputs[i] = transaction.Output{Address: output.Address, ScriptPubKey: info.ScriptPubKey, Value: output.Value}
+	}
+
+	return outputs, nil
+}
+
+// decodeUTXOs decode the hex scripts of the UTXOs
+func decodeUTXOs(reqUTXOs []request.UTXO) ([]transaction.UTXO, error) {
+	var utxos = make([]transaction.UTXO, len(reqUTXOs))
+	for i, utxo := range reqUTXOs {
+		scriptPubKey, err := hex.DecodeString(utxo.ScriptPubKey)
+		if err != nil {
+			return nil, err
+		}
+		witnessScript, err := hex.DecodeString(utxo.WitnessScript)
+		if err != nil {
+			return nil, err
+		}
+		redeemScript, err := hex.DecodeString(utxo.RedeemScript)
+		if err != nil {
+			return nil, err
+		}
+		utxos[i] = transaction.UTXO{
+			TxID:          utxo.TxID,
+			Vout:          utxo.Vout,
+			Value:         utxo.Value,
+			ScriptPubKey:  scriptPubKey,
+			WitnessScript: witnessScript,
+			RedeemScript:  redeemScript,
+			Path:          utxo.Path,
+		}
+	}
+
+	return utxos, nil
+}
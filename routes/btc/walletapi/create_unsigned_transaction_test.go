@@ -0,0 +1,158 @@
+package walletapi
+
+import (
+	"bytes"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRoute_CreateUnsignedTransaction_ReturnNormal(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	seed, _ := hex.DecodeString("5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4")
+	params := request.UnsignedTransaction{
+		Seed: seed,
+		UTXOs: []request.UTXO{
+			{TxID: "4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b", Vout: 1, Value: 500000, ScriptPubKey: "0014751e76e8199196d454941c45d1b3a323f1433bd6", Path: "m/84'/0'/0'/0/0"},
+		},
+		Outputs:    []request.TxOutput{{Address: "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", Value: 300000}},
+		FeeRate:    1,
+		ChangePath: "m/84'/0'/0'/1/0",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreateUnsignedTransaction(w, r)
+
+	var res response.UnsignedTransaction
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	assert.Equal(t, "largest_first", res.Algorithm, "Incorrect algorithm")
+	assert.Equal(t, int64(141), res.Fee, "Incorrect fee")
+	assert.Equal(t, int64(199859), res.ChangeValue, "Incorrect change")
+	assert.NotEmpty(t, res.ChangeAddress, "Expected a change address")
+	assert.Equal(t, "m/84'/0'/0'/0/0", res.Inputs[0].Path, "Incorrect input path")
+	assert.Equal(t, "0200000001", res.Hex[:10], "Incorrect transaction hex")
+}
+
+func TestRoute_CreateUnsignedTransaction_ReturnInsufficientFundsError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	seed, _ := hex.DecodeString("5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4")
+	params := request.UnsignedTransaction{
+		Seed: seed,
+		UTXOs: []request.UTXO{
+			{TxID: "4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b", Vout: 1, Value: 5000, ScriptPubKey: "0014751e76e8199196d454941c45d1b3a323f1433bd6"},
+		},
+		Outputs:    []request.TxOutput{{Address: "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", Value: 300000}},
+		FeeRate:    1,
+		ChangePath: "m/84'/0'/0'/1/0",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreateUnsignedTransaction(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedCode = "INSUFFICIENT_FUNDS"
+
+	assert.Equal(t, expectedCode, res.Code, "Expected error error: insufficient funds")
+}
+
+func TestRoute_CreateUnsignedTransaction_ReturnInvalidAddressError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	seed, _ := hex.DecodeString("5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4")
+	params := request.UnsignedTransaction{
+		Seed: seed,
+		UTXOs: []request.UTXO{
+			{TxID: "4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b", Vout: 1, Value: 500000, ScriptPubKey: "0014751e76e8199196d454941c45d1b3a323f1433bd6"},
+		},
+		Outputs:    []request.TxOutput{{Address: "tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7", Value: 300000}},
+		FeeRate:    1,
+		ChangePath: "m/84'/0'/0'/1/0",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreateUnsignedTransaction(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedCode = "INVALID_ADDRESS"
+
+	assert.Equal(t, expectedCode, res.Code, "Expected error error: testnet address on mainnet")
+}
+
+func TestRoute_CreateUnsignedTransaction_ReturnValueTooLargeError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	seed, _ := hex.DecodeString("5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4")
+	params := request.UnsignedTransaction{
+		Seed: seed,
+		UTXOs: []request.UTXO{
+			{TxID: "4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b", Vout: 1, Value: 100000, ScriptPubKey: "0014751e76e8199196d454941c45d1b3a323f1433bd6"},
+		},
+		Outputs: []request.TxOutput{
+			{Address: "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", Value: 9000000000000000000},
+			{Address: "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", Value: 9000000000000000000},
+		},
+		FeeRate:    1,
+		ChangePath: "m/84'/0'/0'/1/0",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreateUnsignedTransaction(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	assert.Equal(t, 400, w.Code, "Expected status 400")
+	assert.Equal(t, "INVALID_INPUT", res.Code, "Expected error: outputs above the bitcoin supply")
+}
+
+func TestRoute_CreateUnsignedTransaction_ReturnMissingRedeemScriptError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	seed, _ := hex.DecodeString("5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4")
+	params := request.UnsignedTransaction{
+		Seed: seed,
+		UTXOs: []request.UTXO{
+			{TxID: "4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b", Vout: 1, Value: 500000, ScriptPubKey: "a914b472a266d0bd89c13706a4132ccfb16f7c3b9fcb87"},
+		},
+		Outputs:    []request.TxOutput{{Address: "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", Value: 300000}},
+		FeeRate:    1,
+		ChangePath: "m/84'/0'/0'/1/0",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreateUnsignedTransaction(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	assert.Equal(t, 400, w.Code, "Expected status 400")
+	assert.Equal(t, "INVALID_INPUT", res.Code, "Expected error: P2SH UTXO without redeem script")
+}
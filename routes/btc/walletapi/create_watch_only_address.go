@@ -0,0 +1,80 @@
+package walletapi
+
+import (
+	"btcwalletapi/cryto/segwit"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// CreateWatchOnlyAddress handle watch-only bitcoin address request, derived from an account extended public key
+func (api *BTCWalletAPI) CreateWatchOnlyAddress(res http.ResponseWriter, req *http.Request) {
+	res.Header().Set("Content-Type", "application/json")
+	var reqBody request.WatchOnly
+	json.NewDecoder(req.Body).Decode(&reqBody)
+
+	// resolve network, the key version decides when none is requested
+	var network *chaincfg.Params
+	if reqBody.Network != "" {
+		var err error
+		network, err = segwit.ParseNetwork(reqBody.Network)
+		if err != nil {
+			log.Println(err)
+			res.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidNetwork))
+			return
+		}
+	}
+
+	// resolve purpose, the key version decides when none is requested
+	purpose, err := segwit.ParsePurpose(reqBody.Purpose)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidInput))
+		return
+	}
+
+	// decode relative path
+	relativePath, err := segwit.ParseRelativePath(reqBody.Path)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidPath))
+		return
+	}
+
+	// create address
+	address, err := segwit.GetWatchOnlyAddress(reqBody.XPub, network, purpose, relativePath[0], relativePath[1], api.uncompressedKeys(reqBody.LegacyUncompressed))
+	switch err {
+	case nil:
+	case segwit.ErrPurposeMismatch:
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidInput))
+		return
+	case segwit.ErrNetworkMismatch:
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidNetwork))
+		return
+	case segwit.ErrInvalidExtendedKey, segwit.ErrUnsupportedKeyVersion, segwit.ErrNotPublicKey:
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidExtendedKey))
+		return
+	default:
+		log.Println(err)
+		res.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+		return
+	}
+
+	json.NewEncoder(res).Encode(response.Address{
+		Address: address,
+	})
+}
@@ -0,0 +1,110 @@
+package walletapi
+
+import (
+	"bytes"
+	"btcwalletapi/http/response"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRoute_CreateWatchOnlyAddress_ReturnNormal(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := struct {
+		XPub string `json:"xpub"`
+		Path string `json:"path"`
+	}{
+		XPub: "xpub6BosfCnifzxcFwrSzQiqu2DBVTshkCXacvNsWGYJVVhhawA7d4R5WSWGFNbi8Aw6ZRc1brxMyWMzG3DSSSSoekkudhUd9yLb6qx39T9nMdj",
+		Path: "0/0",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreateWatchOnlyAddress(w, r)
+
+	var res response.Address
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	assert.Equal(t, "1", res.Address[:1], "Expected a legacy address")
+}
+
+func TestRoute_CreateWatchOnlyAddress_ReturnInvalidExtendedKeyError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := struct {
+		XPub string `json:"xpub"`
+		Path string `json:"path"`
+	}{
+		XPub: "xpub6BosfCnifzxcFwrSzQiqu2DBVTshkCXacvNsWGYJVVhhawA7d4R5WSWGFNbi8Aw6ZRc1brxMyWMzG3DSSSSoekkudhUd9yLb6qx39T9nMdk",
+		Path: "0/0",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreateWatchOnlyAddress(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedCode = "INVALID_EXTENDED_KEY"
+
+	assert.Equal(t, expectedCode, res.Code, "Expected error error: invalid extended key")
+}
+
+func TestRoute_CreateWatchOnlyAddress_ReturnOffCurveKeyError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	// valid checksum, public key off secp256k1
+	params := struct {
+		XPub string `json:"xpub"`
+		Path string `json:"path"`
+	}{
+		XPub: "xpub6BosfCnifzxcFwrSzQiqu2DBVTshkCXacvNsWGYJVVhhawA7d4R5WSWGFKkRcWDK5VC4SyKSw3GQmHPS1tovwdwXUEmFb8fpwVZTTvTbJ34",
+		Path: "0/0",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreateWatchOnlyAddress(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	assert.Equal(t, 400, w.Code, "Expected status 400")
+	assert.Equal(t, "INVALID_EXTENDED_KEY", res.Code, "Expected error: public key off the curve")
+}
+
+func TestRoute_CreateWatchOnlyAddress_BIP86_ReturnNormal(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	// BIP86 account xpub of the abandon...about mnemonic
+	params := struct {
+		XPub    string `json:"xpub"`
+		Path    string `json:"path"`
+		Purpose uint32 `json:"purpose"`
+	}{
+		XPub:    "xpub6BgBgsespWvERF3LHQu6CnqdvfEvtMcQjYrcRzx53QJjSxarj2afYWcLteoGVky7D3UKDP9QyrLprQ3VCECoY49yfdDEHGCtMMj92pReUsQ",
+		Path:    "0/0",
+		Purpose: 86,
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.CreateWatchOnlyAddress(w, r)
+
+	var res response.Address
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	assert.Equal(t, "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr", res.Address, "Incorrect BIP86 address")
+}
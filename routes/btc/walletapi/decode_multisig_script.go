@@ -0,0 +1,85 @@
+package walletapi
+
+import (
+	"btcwalletapi/cryto/multisig"
+	"btcwalletapi/cryto/segwit"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/btcsuite/btcd/txscript"
+)
+
+// DecodeMultiSigScript handle multisig redeem or witness script decoding request
+func (api *BTCWalletAPI) DecodeMultiSigScript(res http.ResponseWriter, req *http.Request) {
+	res.Header().Set("Content-Type", "application/json")
+	var reqBody request.DecodeScript
+	json.NewDecoder(req.Body).Decode(&reqBody)
+
+	// decode script
+	decoded, err := multisig.DecodeScript(reqBody.Script)
+	var code string
+	switch err {
+	case nil:
+	case multisig.ErrInvalidScriptHex:
+		code = response.ErrInvalidScript
+	case multisig.ErrWitnessProgramScript:
+		code = response.ErrWitnessProgram
+	case multisig.ErrInvalidPubKey:
+		code = response.ErrInvalidPublicKey
+	default:
+		code = response.ErrNonStandardScript
+	}
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(code))
+		return
+	}
+
+	var result = response.DecodedScript{
+		M:          decoded.M,
+		N:          decoded.N,
+		PublicKeys: make([]response.DecodedPublicKey, 0, decoded.N),
+		Addresses:  make(map[string]response.ScriptAddresses, len(segwit.SupportedNetworks)),
+	}
+	for _, publicKey := range decoded.PublicKeys {
+		result.PublicKeys = append(result.PublicKeys, response.DecodedPublicKey{
+			PublicKey:  hex.EncodeToString(publicKey),
+			Compressed: len(publicKey) == 33,
+		})
+	}
+	result.ASM, err = txscript.DisasmString(decoded.Multisig)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+		return
+	}
+
+	// encode addresses for every network, segwit ones only with compressed keys
+	for _, name := range segwit.SupportedNetworks {
+		network, _ := segwit.ParseNetwork(name)
+
+		var addresses response.ScriptAddresses
+		addresses.P2SH, err = decoded.Address(multisig.ScriptTypeP2SH, network)
+		if err == nil && decoded.Compressed() {
+			addresses.P2SHP2WSH, err = decoded.Address(multisig.ScriptTypeP2SHP2WSH, network)
+		}
+		if err == nil && decoded.Compressed() {
+			addresses.P2WSH, err = decoded.Address(multisig.ScriptTypeP2WSH, network)
+		}
+		if err != nil {
+			log.Println(err)
+			res.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+			return
+		}
+		result.Addresses[name] = addresses
+	}
+
+	json.NewEncoder(res).Encode(result)
+}
@@ -0,0 +1,86 @@
+package walletapi
+
+import (
+	"bytes"
+	"btcwalletapi/http/response"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRoute_DecodeMultiSigScript_ReturnNormal(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := struct {
+		Script string `json:"script"`
+	}{
+		Script: "522103a882d414e478039cd5b52a92ffb13dd5e6bd4515497439dffd691a0f12af957521036ce31db9bdd543e72fe3039a1f1c047dab87037c36a669ff90e28da1848f640d210311ffd36c70776538d079fbae117dc38effafb33304af83ce4894589747aee1ef53ae",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.DecodeMultiSigScript(w, r)
+
+	var res response.DecodedScript
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	assert.Equal(t, 2, res.M, "Incorrect m")
+	assert.Equal(t, 3, res.N, "Incorrect n")
+	assert.True(t, res.PublicKeys[0].Compressed, "Expected a compressed key")
+	assert.Equal(t, "3MsXykid1v9i3FWP4Fbj8vVLKrjPbBQCbP", res.Addresses["mainnet"].P2SH, "Incorrect P2SH address")
+	assert.Equal(t, "3F7ULEo5xPC4B9rkN3T8tWL8ZfTCvycAx4", res.Addresses["mainnet"].P2SHP2WSH, "Incorrect P2SH-P2WSH address")
+	assert.Equal(t, "bc1qznadz5sy773nv3pr2n2jmymcyzkrn64tttz5640nz6988zmemzfs3dn6jt", res.Addresses["mainnet"].P2WSH, "Incorrect P2WSH address")
+	assert.Equal(t, "bcrt1q", res.Addresses["regtest"].P2WSH[:6], "Incorrect regtest P2WSH address")
+	assert.Len(t, res.Addresses, 4, "Expected addresses for every network")
+}
+
+func TestRoute_DecodeMultiSigScript_ReturnNonStandardScriptError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := struct {
+		Script string `json:"script"`
+	}{
+		Script: "76a914111111111111111111111111111111111111111188ac",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.DecodeMultiSigScript(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedCode = "NON_STANDARD_SCRIPT"
+
+	assert.Equal(t, expectedCode, res.Code, "Expected error error: P2PKH script")
+}
+
+func TestRoute_DecodeMultiSigScript_ReturnInvalidScriptError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := struct {
+		Script string `json:"script"`
+	}{
+		Script: "not a script",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.DecodeMultiSigScript(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedCode = "INVALID_SCRIPT"
+
+	assert.Equal(t, expectedCode, res.Code, "Expected error error: not hex")
+}
@@ -0,0 +1,99 @@
+package walletapi
+
+import (
+	"btcwalletapi/cryto/descriptor"
+	"btcwalletapi/cryto/segwit"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// DeriveDescriptor handle output descriptor request, giving the addresses of an index range
+func (api *BTCWalletAPI) DeriveDescriptor(res http.ResponseWriter, req *http.Request) {
+	res.Header().Set("Content-Type", "application/json")
+	var reqBody request.DeriveDescriptor
+	json.NewDecoder(req.Body).Decode(&reqBody)
+
+	// validate range
+	if reqBody.Count == 0 {
+		reqBody.Count = 1
+	}
+	if reqBody.Count > api.maxRangeCount() {
+		log.Printf("count %d out of range [1, %d]", reqBody.Count, api.maxRangeCount())
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidInput))
+		return
+	}
+
+	// resolve network, the extended key version decides when none is requested
+	var network *chaincfg.Params
+	var err error
+	if reqBody.Network != "" {
+		network, err = segwit.ParseNetwork(reqBody.Network)
+		if err != nil {
+			log.Println(err)
+			res.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidNetwork))
+			return
+		}
+	}
+
+	parsed, err := descriptor.Parse(reqBody.Descriptor, network)
+	switch err {
+	case nil:
+	case segwit.ErrNetworkMismatch:
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidNetwork))
+		return
+	default:
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidDescriptor))
+		return
+	}
+
+	addresses, err := parsed.AddressRange(reqBody.Start, reqBody.Count)
+	switch err {
+	case nil:
+	case descriptor.ErrNotRanged, segwit.ErrIndexOutOfRange:
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidInput))
+		return
+	default:
+		log.Println(err)
+		res.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+		return
+	}
+
+	var result = response.Descriptor{
+		Descriptor: parsed.String(),
+		Type:       string(parsed.Type),
+		ScriptType: string(parsed.ScriptType),
+		Ranged:     parsed.Ranged(),
+		Network:    parsed.Network.Name,
+		Addresses:  make([]response.DescriptorAddress, 0, len(addresses)),
+		NextStart:  reqBody.Start + reqBody.Count,
+	}
+	for _, address := range addresses {
+		var publicKeys = make([]string, len(address.PublicKeys))
+		for i, publicKey := range address.PublicKeys {
+			publicKeys[i] = hex.EncodeToString(publicKey)
+		}
+		result.Addresses = append(result.Addresses, response.DescriptorAddress{
+			Index:        address.Index,
+			Address:      address.Address,
+			ScriptPubKey: hex.EncodeToString(address.ScriptPubKey),
+			PublicKeys:   publicKeys,
+		})
+	}
+
+	json.NewEncoder(res).Encode(result)
+}
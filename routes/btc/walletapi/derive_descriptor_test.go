@@ -0,0 +1,61 @@
+package walletapi
+
+import (
+	"bytes"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRoute_DeriveDescriptor_ReturnNormal(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := request.DeriveDescriptor{
+		Descriptor: "wpkh([73c5da0a/84h/0h/0h]xpub6CatWdiZiodmUeTDp8LT5or8nmbKNcuyvz7WyksVFkKB4RHwCD3XyuvPEbvqAQY3rAPshWcMLoP2fMFMKHPJ4ZeZXYVUhLv1VMrjPC7PW6V/0/*)",
+		Start:      1,
+		Count:      2,
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.DeriveDescriptor(w, r)
+
+	var res response.Descriptor
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	assert.Equal(t, params.Descriptor+"#afwvtk2s", res.Descriptor, "Expected the descriptor with its checksum")
+	assert.Equal(t, "wpkh", res.Type, "Incorrect type")
+	assert.True(t, res.Ranged, "Expected a ranged descriptor")
+	assert.Equal(t, "mainnet", res.Network, "Incorrect network")
+	assert.Len(t, res.Addresses, 2, "Incorrect number of addresses")
+	assert.Equal(t, uint32(1), res.Addresses[0].Index, "Incorrect index")
+	assert.Equal(t, "bc1qnjg0jd8228aq7egyzacy8cys3knf9xvrerkf9g", res.Addresses[0].Address, "Incorrect address")
+	assert.Equal(t, uint32(3), res.NextStart, "Incorrect next start")
+}
+
+func TestRoute_DeriveDescriptor_ReturnInvalidDescriptorError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := request.DeriveDescriptor{
+		Descriptor: "wpkh([73c5da0a/84h/0h/0h]xpub6CatWdiZiodmUeTDp8LT5or8nmbKNcuyvz7WyksVFkKB4RHwCD3XyuvPEbvqAQY3rAPshWcMLoP2fMFMKHPJ4ZeZXYVUhLv1VMrjPC7PW6V/0/*)#afwvtk2q",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.DeriveDescriptor(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedCode = "INVALID_DESCRIPTOR"
+
+	assert.Equal(t, expectedCode, res.Code, "Expected error error: invalid checksum")
+}
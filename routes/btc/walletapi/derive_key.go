@@ -0,0 +1,81 @@
+package walletapi
+
+import (
+	"btcwalletapi/cryto/segwit"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// DeriveKey handle BIP32 key derivation request at a path of any depth, from a seed or an extended public key
+func (api *BTCWalletAPI) DeriveKey(res http.ResponseWriter, req *http.Request) {
+	res.Header().Set("Content-Type", "application/json")
+	var reqBody request.DeriveKey
+	json.NewDecoder(req.Body).Decode(&reqBody)
+
+	// resolve network, falling back to the server default
+	var network, err = api.network(reqBody.Network)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidNetwork))
+		return
+	}
+
+	// decode path
+	var components []uint32
+	var relative bool
+	if reqBody.Strict {
+		components, err = segwit.ParseDerivationPath(reqBody.Path, network)
+	} else {
+		components, relative, err = segwit.ParsePath(reqBody.Path)
+	}
+	if err == nil && reqBody.XPub != "" && (reqBody.Strict || !relative) {
+		// an extended public key only derives relative paths
+		err = segwit.ErrInvalidPath
+	}
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidPath))
+		return
+	}
+
+	// derive key
+	var info *segwit.ExtendedKeyInfo
+	if reqBody.XPub != "" {
+		info, err = segwit.DeriveFromExtendedKey(reqBody.XPub, components)
+	} else {
+		info, err = segwit.DeriveFromSeed(reqBody.Seed, network, components)
+	}
+	switch err {
+	case nil:
+	case segwit.ErrHardenedPublicDerivation, segwit.ErrPathTooDeep:
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidPath))
+		return
+	case segwit.ErrInvalidExtendedKey, segwit.ErrUnsupportedKeyVersion, segwit.ErrNotPublicKey:
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidExtendedKey))
+		return
+	default:
+		log.Println(err)
+		res.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+		return
+	}
+
+	json.NewEncoder(res).Encode(response.DerivedKey{
+		Path:              info.Path,
+		PublicKey:         hex.EncodeToString(info.PublicKey),
+		ChainCode:         hex.EncodeToString(info.ChainCode),
+		Depth:             info.Depth,
+		ParentFingerprint: hex.EncodeToString(info.ParentFingerprint),
+		ExtendedPublicKey: info.ExtendedPublicKey,
+	})
+}
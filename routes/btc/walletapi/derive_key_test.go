@@ -0,0 +1,67 @@
+package walletapi
+
+import (
+	"bytes"
+	"btcwalletapi/http/response"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRoute_DeriveKey_ReturnNormal(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	seed, _ := hex.DecodeString("5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4")
+	params := struct {
+		Seed []byte `json:"seed"`
+		Path string `json:"path"`
+	}{
+		Seed: seed,
+		Path: "m/48h/0h/0h/2h",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.DeriveKey(w, r)
+
+	var res response.DerivedKey
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	assert.Equal(t, "m/48'/0'/0'/2'", res.Path, "Incorrect normalized path")
+	assert.Equal(t, byte(4), res.Depth, "Incorrect depth")
+	assert.Len(t, res.PublicKey, 66, "Expected a compressed public key")
+	assert.Equal(t, "xpub", res.ExtendedPublicKey[:4], "Expected a mainnet extended public key")
+}
+
+func TestRoute_DeriveKey_ReturnInvalidPathError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	seed, _ := hex.DecodeString("5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4")
+	params := struct {
+		Seed   []byte `json:"seed"`
+		Path   string `json:"path"`
+		Strict bool   `json:"strict"`
+	}{
+		Seed:   seed,
+		Path:   "m/48'/0'/0'/2'/0/0",
+		Strict: true,
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.DeriveKey(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedCode = "INVALID_PATH"
+
+	assert.Equal(t, expectedCode, res.Code, "Expected error error: strict path validation")
+}
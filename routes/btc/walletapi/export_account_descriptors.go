@@ -0,0 +1,69 @@
+package walletapi
+
+import (
+	"btcwalletapi/cryto/descriptor"
+	"btcwalletapi/cryto/segwit"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// ExportAccountDescriptors handle account descriptors request, giving the receive and change descriptors
+// of a seed account in public and private form, ready for Bitcoin Core importdescriptors
+func (api *BTCWalletAPI) ExportAccountDescriptors(res http.ResponseWriter, req *http.Request) {
+	res.Header().Set("Content-Type", "application/json")
+	var reqBody request.AccountDescriptors
+	json.NewDecoder(req.Body).Decode(&reqBody)
+
+	// resolve network, falling back to the server default
+	var network, err = api.network(reqBody.Network)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidNetwork))
+		return
+	}
+
+	// resolve seed, from the mnemonic when no raw seed is given
+	hdSeed, err := seed(reqBody.Seed, reqBody.Mnemonic, reqBody.Passphrase)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidMnemonic))
+		return
+	}
+
+	// decode account path
+	accountPath, err := segwit.ParseAccountPath(reqBody.Path, network)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidPath))
+		return
+	}
+
+	descriptors, err := descriptor.Account(hdSeed, network, accountPath[0], accountPath[1], accountPath[2])
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+		return
+	}
+
+	var result = response.AccountDescriptors{
+		Path:              reqBody.Path,
+		MasterFingerprint: hex.EncodeToString(descriptors.Fingerprint),
+		Receive:           descriptors.Receive,
+		Change:            descriptors.Change,
+	}
+	// xprv descriptors only leave the service when bitcoin.key_export is enabled
+	if api.config.Bitcoin.KeyExport {
+		result.ReceivePrivate = descriptors.ReceivePrivate
+		result.ChangePrivate = descriptors.ChangePrivate
+	}
+
+	json.NewEncoder(res).Encode(result)
+}
@@ -0,0 +1,83 @@
+package walletapi
+
+import (
+	"bytes"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRoute_ExportAccountDescriptors_ReturnNormal(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := request.AccountDescriptors{
+		Mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		Path:     "m/84'/0'/0'",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.ExportAccountDescriptors(w, r)
+
+	var res response.AccountDescriptors
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	var expectedReceive = "wpkh([73c5da0a/84'/0'/0']xpub6CatWdiZiodmUeTDp8LT5or8nmbKNcuyvz7WyksVFkKB4RHwCD3XyuvPEbvqAQY3rAPshWcMLoP2fMFMKHPJ4ZeZXYVUhLv1VMrjPC7PW6V/0/*)#"
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	assert.Equal(t, "73c5da0a", res.MasterFingerprint, "Incorrect fingerprint")
+	assert.True(t, strings.HasPrefix(res.Receive, expectedReceive), "Incorrect receive descriptor")
+	assert.True(t, strings.Contains(res.Change, "/1/*)#"), "Incorrect change descriptor")
+	assert.Empty(t, res.ReceivePrivate, "Expected no private descriptor: key export disabled")
+	assert.Empty(t, res.ChangePrivate, "Expected no private descriptor: key export disabled")
+}
+
+func TestRoute_ExportAccountDescriptors_ReturnPrivate(t *testing.T) {
+	var api = BTCWalletAPI{}
+	api.config.Bitcoin.KeyExport = true
+
+	params := request.AccountDescriptors{
+		Mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		Path:     "m/84'/0'/0'",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.ExportAccountDescriptors(w, r)
+
+	var res response.AccountDescriptors
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	assert.True(t, strings.HasPrefix(res.ReceivePrivate, "wpkh([73c5da0a/84'/0'/0']xprv"), "Incorrect private receive descriptor")
+	assert.True(t, strings.Contains(res.ChangePrivate, "/1/*)#"), "Incorrect private change descriptor")
+}
+
+func TestRoute_ExportAccountDescriptors_ReturnInvalidPathError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := request.AccountDescriptors{
+		Mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		Path:     "m/84'/0'/0'/0/0",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.ExportAccountDescriptors(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedCode = "INVALID_PATH"
+
+	assert.Equal(t, expectedCode, res.Code, "Expected error error: not an account path")
+}
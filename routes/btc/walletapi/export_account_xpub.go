@@ -0,0 +1,54 @@
+package walletapi
+
+import (
+	"btcwalletapi/cryto/segwit"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// ExportAccountXPub handle account extended public key request, serialized with SLIP-132 version bytes
+func (api *BTCWalletAPI) ExportAccountXPub(res http.ResponseWriter, req *http.Request) {
+	res.Header().Set("Content-Type", "application/json")
+	var reqBody request.AccountXPub
+	json.NewDecoder(req.Body).Decode(&reqBody)
+
+	// resolve network, falling back to the server default
+	var network, err = api.network(reqBody.Network)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidNetwork))
+		return
+	}
+
+	// decode account path
+	accountPath, err := segwit.ParseAccountPath(reqBody.Path, network)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidPath))
+		return
+	}
+
+	// derive account key
+	xpub, fingerprint, err := segwit.GetAccountExtendedPublicKey(
+		reqBody.Seed,
+		network,
+		accountPath[0], accountPath[1], accountPath[2],
+	)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+		return
+	}
+
+	json.NewEncoder(res).Encode(response.ExtendedKey{
+		Path:              reqBody.Path,
+		ExtendedKey:       xpub,
+		MasterFingerprint: fingerprint,
+	})
+}
@@ -0,0 +1,66 @@
+package walletapi
+
+import (
+	"bytes"
+	"btcwalletapi/http/response"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRoute_ExportAccountXPub_ReturnNormal(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	seed, _ := hex.DecodeString("5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4")
+	params := struct {
+		Seed []byte `json:"seed"`
+		Path string `json:"path"`
+	}{
+		Seed: seed,
+		Path: "m/84'/0'/0'",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.ExportAccountXPub(w, r)
+
+	var res response.ExtendedKey
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedKey = "zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs"
+
+	assert.Equal(t, expectedKey, res.ExtendedKey, "Incorrect extended key")
+	assert.Equal(t, "73c5da0a", res.MasterFingerprint, "Incorrect master fingerprint")
+}
+
+func TestRoute_ExportAccountXPub_ReturnInvalidPathError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	seed, _ := hex.DecodeString("5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4")
+	params := struct {
+		Seed []byte `json:"seed"`
+		Path string `json:"path"`
+	}{
+		Seed: seed,
+		Path: "m/84'/0'/0'/0/0",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.ExportAccountXPub(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedCode = "INVALID_PATH"
+
+	assert.Equal(t, expectedCode, res.Code, "Expected error error: invalid path")
+}
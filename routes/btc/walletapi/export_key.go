@@ -0,0 +1,92 @@
+package walletapi
+
+import (
+	"btcwalletapi/cryto/segwit"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+)
+
+var errKeyExportDisabled = errors.New("private key export is disabled")
+
+// ExportKey handle private key export request, giving the WIF and hex keys at a path
+// when bitcoin.key_export is enabled
+func (api *BTCWalletAPI) ExportKey(res http.ResponseWriter, req *http.Request) {
+	res.Header().Set("Content-Type", "application/json")
+	if !api.config.Bitcoin.KeyExport {
+		log.Println(errKeyExportDisabled)
+		res.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrKeyExportDisabled))
+		return
+	}
+
+	var reqBody request.ExportKey
+	json.NewDecoder(req.Body).Decode(&reqBody)
+
+	// resolve network, falling back to the server default
+	var network, err = api.network(reqBody.Network)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidNetwork))
+		return
+	}
+
+	// resolve seed, from the mnemonic when no raw seed is given
+	hdSeed, err := seed(reqBody.Seed, reqBody.Mnemonic, reqBody.Passphrase)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidMnemonic))
+		return
+	}
+	km, err := segwit.NewKeyManager(hdSeed, network)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrSeed))
+		return
+	}
+
+	// decode path
+	derivationPath, err := segwit.ParseDerivationPath(reqBody.Path, network)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidPath))
+		return
+	}
+	key, err := km.GetKey(derivationPath[0], derivationPath[1], derivationPath[2], derivationPath[3], derivationPath[4])
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+		return
+	}
+
+	// compressed unless legacy uncompressed keys are configured
+	var compressed = !api.uncompressedKeys(false)
+	if reqBody.Compressed != nil {
+		compressed = *reqBody.Compressed
+	}
+	exported, err := key.Export(derivationPath[0], compressed)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+		return
+	}
+
+	json.NewEncoder(res).Encode(response.ExportedKey{
+		Path:       exported.Path,
+		WIF:        exported.WIF,
+		PrivateKey: hex.EncodeToString(exported.PrivateKey),
+		PublicKey:  hex.EncodeToString(exported.PublicKey),
+		Compressed: exported.Compressed,
+		Address:    exported.Address,
+	})
+}
@@ -0,0 +1,60 @@
+package walletapi
+
+import (
+	"bytes"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRoute_ExportKey_ReturnNormal(t *testing.T) {
+	var api = BTCWalletAPI{}
+	api.config.Bitcoin.KeyExport = true
+
+	params := request.ExportKey{
+		Mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		Path:     "m/84'/0'/0'/0/0",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.ExportKey(w, r)
+
+	var res response.ExportedKey
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	// BIP84 test vector
+	assert.Equal(t, "KyZpNDKnfs94vbrwhJneDi77V6jF64PWPF8x5cdJb8ifgg2DUc9d", res.WIF, "Incorrect WIF")
+	assert.Equal(t, "0330d54fd0dd420a6e5f8d3624f5f3482cae350f79d5f0753bf5beef9c2d91af3c", res.PublicKey, "Incorrect public key")
+	assert.Equal(t, "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu", res.Address, "Incorrect address")
+}
+
+func TestRoute_ExportKey_ReturnKeyExportDisabledError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := request.ExportKey{
+		Mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		Path:     "m/84'/0'/0'/0/0",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.ExportKey(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedCode = "KEY_EXPORT_DISABLED"
+
+	assert.Equal(t, 403, w.Code, "Expected forbidden status")
+	assert.Equal(t, expectedCode, res.Code, "Expected error error: key export not enabled")
+}
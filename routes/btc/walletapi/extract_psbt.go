@@ -0,0 +1,65 @@
+package walletapi
+
+import (
+	"btcwalletapi/cryto/psbt"
+	"btcwalletapi/cryto/transaction"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/btcsuite/btcd/wire"
+)
+
+// ExtractPSBT handle transaction extraction request of a finalized PSBT
+func (api *BTCWalletAPI) ExtractPSBT(res http.ResponseWriter, req *http.Request) {
+	res.Header().Set("Content-Type", "application/json")
+	var reqBody request.PSBT
+	json.NewDecoder(req.Body).Decode(&reqBody)
+
+	packet, err := psbt.ParseBase64(reqBody.PSBT)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidPSBT))
+		return
+	}
+
+	tx, err := psbt.Extract(packet)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrPSBTIncomplete))
+		return
+	}
+
+	result, err := signedTransactionResponse(tx)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+		return
+	}
+
+	json.NewEncoder(res).Encode(result)
+}
+
+// signedTransactionResponse describe a signed transaction with its txid and virtual size
+func signedTransactionResponse(tx *wire.MsgTx) (response.SignedTransaction, error) {
+	var serialized bytes.Buffer
+	if err := tx.Serialize(&serialized); err != nil {
+		return response.SignedTransaction{}, err
+	}
+
+	// weight counts non witness bytes four times
+	var weight = int64(tx.SerializeSizeStripped()*3 + tx.SerializeSize())
+
+	return response.SignedTransaction{
+		Hex:         hex.EncodeToString(serialized.Bytes()),
+		TxID:        tx.TxHash().String(),
+		VirtualSize: transaction.VirtualSize(weight),
+	}, nil
+}
@@ -0,0 +1,49 @@
+package walletapi
+
+import (
+	"bytes"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRoute_ExtractPSBT_ReturnNormal(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	var finalized = finalizeTestPSBT(t, signTestPSBT(t, createTestPSBT(t, 300000).PSBT).PSBT)
+	paramsByte, _ := json.Marshal(request.PSBT{PSBT: finalized.PSBT})
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.ExtractPSBT(w, r)
+
+	var res response.SignedTransaction
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	assert.Equal(t, "020000000001", res.Hex[:12], "Expected a segwit serialization")
+	assert.Len(t, res.TxID, 64, "Incorrect txid")
+	assert.Equal(t, int64(141), res.VirtualSize, "Incorrect virtual size")
+}
+
+func TestRoute_ExtractPSBT_ReturnIncompleteError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	paramsByte, _ := json.Marshal(request.PSBT{PSBT: createTestPSBT(t, 300000).PSBT})
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.ExtractPSBT(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedCode = "PSBT_INCOMPLETE"
+
+	assert.Equal(t, expectedCode, res.Code, "Expected error error: unsigned PSBT")
+}
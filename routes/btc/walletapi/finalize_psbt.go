@@ -0,0 +1,38 @@
+package walletapi
+
+import (
+	"btcwalletapi/cryto/psbt"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// FinalizePSBT handle PSBT finalization request, inputs missing signatures are left as they are
+func (api *BTCWalletAPI) FinalizePSBT(res http.ResponseWriter, req *http.Request) {
+	res.Header().Set("Content-Type", "application/json")
+	var reqBody request.PSBT
+	json.NewDecoder(req.Body).Decode(&reqBody)
+
+	packet, err := psbt.ParseBase64(reqBody.PSBT)
+	if err == nil {
+		err = psbt.Finalize(packet)
+	}
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidPSBT))
+		return
+	}
+
+	result, err := psbtResponse(packet)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+		return
+	}
+
+	json.NewEncoder(res).Encode(result)
+}
@@ -0,0 +1,60 @@
+package walletapi
+
+import (
+	"bytes"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+// finalizeTestPSBT finalize a PSBT through the API
+func finalizeTestPSBT(t *testing.T, encoded string) response.PSBT {
+	var api = BTCWalletAPI{}
+
+	paramsByte, _ := json.Marshal(request.PSBT{PSBT: encoded})
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.FinalizePSBT(w, r)
+
+	var res response.PSBT
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	return res
+}
+
+func TestRoute_FinalizePSBT_ReturnNormal(t *testing.T) {
+	var res = finalizeTestPSBT(t, signTestPSBT(t, createTestPSBT(t, 300000).PSBT).PSBT)
+
+	assert.True(t, res.Complete, "Expected a complete PSBT")
+	assert.True(t, res.Inputs[0].Finalized, "Expected the input finalized")
+	assert.Equal(t, 0, res.Inputs[0].Signatures, "Expected the partial signatures dropped")
+
+	// nothing to finalize without signatures
+	res = finalizeTestPSBT(t, createTestPSBT(t, 300000).PSBT)
+
+	assert.False(t, res.Complete, "Expected an incomplete PSBT")
+}
+
+func TestRoute_FinalizePSBT_ReturnInvalidPSBTError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	paramsByte, _ := json.Marshal(request.PSBT{PSBT: "bm90IGEgcHNidA=="})
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.FinalizePSBT(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedCode = "INVALID_PSBT"
+
+	assert.Equal(t, expectedCode, res.Code, "Expected error error: no PSBT magic")
+}
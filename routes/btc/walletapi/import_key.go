@@ -0,0 +1,58 @@
+package walletapi
+
+import (
+	"btcwalletapi/cryto/segwit"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// ImportKey handle WIF private key import request, giving the addresses the key controls
+func (api *BTCWalletAPI) ImportKey(res http.ResponseWriter, req *http.Request) {
+	res.Header().Set("Content-Type", "application/json")
+	var reqBody request.ImportKey
+	json.NewDecoder(req.Body).Decode(&reqBody)
+
+	// resolve network, the WIF version decides when none is requested
+	var network *chaincfg.Params
+	var err error
+	if reqBody.Network != "" {
+		network, err = segwit.ParseNetwork(reqBody.Network)
+		if err != nil {
+			log.Println(err)
+			res.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidNetwork))
+			return
+		}
+	}
+
+	imported, err := segwit.ImportWIF(reqBody.WIF, network)
+	switch err {
+	case nil:
+	case segwit.ErrWIFNetwork:
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidNetwork))
+		return
+	default:
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidWIF))
+		return
+	}
+
+	json.NewEncoder(res).Encode(response.ImportedKey{
+		Network:    imported.Network.Name,
+		PublicKey:  hex.EncodeToString(imported.PublicKey),
+		Compressed: imported.Compressed,
+		P2PKH:      imported.P2PKH,
+		P2SHP2WPKH: imported.P2SHP2WPKH,
+		P2WPKH:     imported.P2WPKH,
+		P2TR:       imported.P2TR,
+	})
+}
@@ -0,0 +1,78 @@
+package walletapi
+
+import (
+	"bytes"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRoute_ImportKey_ReturnNormal(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := request.ImportKey{
+		WIF: "L3VFeEujGtevx9w18HD1fhRbCH67Az2dpCymeRE1SoPK6XQtaN2k",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.ImportKey(w, r)
+
+	var res response.ImportedKey
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	assert.Equal(t, "mainnet", res.Network, "Expected the WIF network")
+	assert.True(t, res.Compressed, "Expected a compressed key")
+	assert.Equal(t, "bc1q9vza2e8x573nczrlzms0wvx3gsqjx7vavgkx0l", res.P2WPKH, "Incorrect P2WPKH address")
+	assert.Equal(t, "bc1ppv609nr0vr25u07u95waq5lucwfm6tde4nydujnu8npg4q75mr5sxq8lt3", res.P2TR, "Incorrect P2TR address")
+}
+
+func TestRoute_ImportKey_ReturnInvalidNetworkError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := request.ImportKey{
+		WIF:     "L3VFeEujGtevx9w18HD1fhRbCH67Az2dpCymeRE1SoPK6XQtaN2k",
+		Network: "testnet3",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.ImportKey(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedCode = "INVALID_NETWORK"
+
+	assert.Equal(t, expectedCode, res.Code, "Expected error error: mainnet WIF on testnet")
+}
+
+func TestRoute_ImportKey_ReturnInvalidWIFError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := request.ImportKey{
+		WIF: "L3VFeEujGtevx9w18HD1fhRbCH67Az2dpCymeRE1SoPK6XQtaN2j",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.ImportKey(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedCode = "INVALID_WIF"
+
+	assert.Equal(t, expectedCode, res.Code, "Expected error error: invalid checksum")
+}
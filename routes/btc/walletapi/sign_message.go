@@ -0,0 +1,83 @@
+package walletapi
+
+import (
+	"btcwalletapi/cryto/message"
+	"btcwalletapi/cryto/segwit"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// SignMessage handle message signing request, proving the ownership of the address at a path
+// with a legacy signature for P2PKH and a BIP322 simple signature for P2WPKH and P2TR
+func (api *BTCWalletAPI) SignMessage(res http.ResponseWriter, req *http.Request) {
+	res.Header().Set("Content-Type", "application/json")
+	var reqBody request.SignMessage
+	json.NewDecoder(req.Body).Decode(&reqBody)
+
+	// resolve network, falling back to the server default
+	var network, err = api.network(reqBody.Network)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidNetwork))
+		return
+	}
+
+	// resolve seed, from the mnemonic when no raw seed is given
+	hdSeed, err := seed(reqBody.Seed, reqBody.Mnemonic, reqBody.Passphrase)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidMnemonic))
+		return
+	}
+	km, err := segwit.NewKeyManager(hdSeed, network)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrSeed))
+		return
+	}
+	km.SetUncompressed(api.uncompressedKeys(reqBody.LegacyUncompressed))
+
+	// decode path
+	derivationPath, err := segwit.ParseDerivationPath(reqBody.Path, network)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidPath))
+		return
+	}
+	key, err := km.GetKey(derivationPath[0], derivationPath[1], derivationPath[2], derivationPath[3], derivationPath[4])
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+		return
+	}
+
+	signature, err := message.Sign(key, derivationPath[0], reqBody.Message)
+	switch err {
+	case nil:
+	case message.ErrUnsupportedAddress:
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidPath))
+		return
+	default:
+		log.Println(err)
+		res.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+		return
+	}
+
+	json.NewEncoder(res).Encode(response.SignedMessage{
+		Address:   signature.Address,
+		Message:   reqBody.Message,
+		Signature: signature.Signature,
+		Format:    string(signature.Format),
+	})
+}
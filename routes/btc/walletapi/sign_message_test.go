@@ -0,0 +1,58 @@
+package walletapi
+
+import (
+	"bytes"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRoute_SignMessage_ReturnNormal(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := request.SignMessage{
+		Message: "Hello World",
+		Path:    "m/84'/0'/0'/0/0",
+		Seed:    []byte{244, 184, 4, 62, 59, 59, 77, 11, 158, 60, 124, 218, 129, 214, 134, 140, 51, 26, 174, 204, 128, 85, 93, 199, 178, 208, 237, 206, 107, 115, 234, 80, 169, 29, 103, 88, 111, 116, 97, 205, 70, 202, 204, 238, 110, 36, 10, 89, 138, 154, 170, 48, 99, 205, 217, 190, 198, 90, 61, 36, 211, 170, 85, 27},
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.SignMessage(w, r)
+
+	var res response.SignedMessage
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	assert.Equal(t, "bc1q84kvjqueplk6h80x89j4mjujvq3svfwcz8pavd", res.Address, "Expected the address of the path")
+	assert.Equal(t, "bip322", res.Format, "Incorrect format")
+	assert.NotEmpty(t, res.Signature, "Expected a signature")
+}
+
+func TestRoute_SignMessage_ReturnInvalidPathError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := request.SignMessage{
+		Message:  "Hello World",
+		Path:     "m/49'/0'/0'/0/0",
+		Mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.SignMessage(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedCode = "INVALID_PATH"
+
+	assert.Equal(t, expectedCode, res.Code, "Expected error error: P2SH-P2WPKH address")
+}
@@ -0,0 +1,73 @@
+package walletapi
+
+import (
+	"btcwalletapi/cryto/psbt"
+	"btcwalletapi/cryto/segwit"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// SignPSBT handle PSBT signing request, every input with a derivation of the seed is signed
+func (api *BTCWalletAPI) SignPSBT(res http.ResponseWriter, req *http.Request) {
+	res.Header().Set("Content-Type", "application/json")
+	var reqBody request.SignPSBT
+	json.NewDecoder(req.Body).Decode(&reqBody)
+
+	packet, err := psbt.ParseBase64(reqBody.PSBT)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidPSBT))
+		return
+	}
+
+	// resolve network, falling back to the server default
+	network, err := api.network(reqBody.Network)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidNetwork))
+		return
+	}
+
+	// resolve seed, from the mnemonic when no raw seed is given
+	hdSeed, err := seed(reqBody.Seed, reqBody.Mnemonic, reqBody.Passphrase)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidMnemonic))
+		return
+	}
+	km, err := segwit.NewKeyManager(hdSeed, network)
+	if err == nil {
+		_, err = km.MasterFingerprint()
+	}
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrSeed))
+		return
+	}
+
+	signed, err := psbt.Sign(packet, km)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidPSBT))
+		return
+	}
+
+	result, err := psbtResponse(packet)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+		return
+	}
+	result.Signed = signed
+
+	json.NewEncoder(res).Encode(result)
+}
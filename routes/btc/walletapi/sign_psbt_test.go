@@ -0,0 +1,63 @@
+package walletapi
+
+import (
+	"bytes"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+// signTestPSBT sign a PSBT with the abandon ... about mnemonic through the API
+func signTestPSBT(t *testing.T, encoded string) response.PSBT {
+	var api = BTCWalletAPI{}
+
+	params := request.SignPSBT{
+		PSBT:     encoded,
+		Mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.SignPSBT(w, r)
+
+	var res response.PSBT
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	return res
+}
+
+func TestRoute_SignPSBT_ReturnNormal(t *testing.T) {
+	var res = signTestPSBT(t, createTestPSBT(t, 300000).PSBT)
+
+	assert.Equal(t, 1, res.Signed, "Incorrect signature count")
+	assert.Equal(t, 1, res.Inputs[0].Signatures, "Expected the input signed")
+	assert.False(t, res.Complete, "Expected a PSBT not finalized")
+}
+
+func TestRoute_SignPSBT_ReturnInvalidPSBTError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := request.SignPSBT{
+		PSBT:     "cHNidP8=",
+		Mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.SignPSBT(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedCode = "INVALID_PSBT"
+
+	assert.Equal(t, expectedCode, res.Code, "Expected error error: truncated PSBT")
+}
@@ -0,0 +1,110 @@
+package walletapi
+
+import (
+	"btcwalletapi/cryto/segwit"
+	"btcwalletapi/cryto/transaction"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/btcsuite/btcd/wire"
+)
+
+// SignTransaction handle raw transaction signing request, every input is signed with the seed key at its path
+func (api *BTCWalletAPI) SignTransaction(res http.ResponseWriter, req *http.Request) {
+	res.Header().Set("Content-Type", "application/json")
+	var reqBody request.SignTransaction
+	json.NewDecoder(req.Body).Decode(&reqBody)
+
+	// decode the unsigned transaction
+	var tx wire.MsgTx
+	serialized, err := hex.DecodeString(reqBody.Hex)
+	if err == nil {
+		err = tx.Deserialize(bytes.NewReader(serialized))
+	}
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidInput))
+		return
+	}
+
+	// decode the spent outputs
+	var prevOuts = make([]transaction.PrevOut, len(reqBody.PrevOuts))
+	for i, prevOut := range reqBody.PrevOuts {
+		scriptPubKey, err := hex.DecodeString(prevOut.ScriptPubKey)
+		if err != nil {
+			log.Println(err)
+			res.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidInput))
+			return
+		}
+		path, err := parseAbsolutePath(prevOut.Path)
+		if err != nil {
+			log.Println(err)
+			res.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidPath))
+			return
+		}
+		prevOuts[i] = transaction.PrevOut{Value: prevOut.Value, ScriptPubKey: scriptPubKey, Path: path}
+	}
+
+	// resolve network, falling back to the server default
+	network, err := api.network(reqBody.Network)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidNetwork))
+		return
+	}
+
+	// resolve seed, from the mnemonic when no raw seed is given
+	hdSeed, err := seed(reqBody.Seed, reqBody.Mnemonic, reqBody.Passphrase)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidMnemonic))
+		return
+	}
+	km, err := segwit.NewKeyManager(hdSeed, network)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrSeed))
+		return
+	}
+
+	err = transaction.Sign(&tx, prevOuts, km)
+	switch err {
+	case nil:
+	case transaction.ErrKeyMismatch:
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidPath))
+		return
+	case transaction.ErrUnsupportedScript, transaction.ErrUnsupportedSigner:
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidScript))
+		return
+	default:
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidInput))
+		return
+	}
+
+	result, err := signedTransactionResponse(&tx)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInternal))
+		return
+	}
+
+	json.NewEncoder(res).Encode(result)
+}
@@ -0,0 +1,67 @@
+package walletapi
+
+import (
+	"bytes"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+// testUnsignedTxHex spend 4a5e1e4b...:1 paying 300000 sat to bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4
+const testUnsignedTxHex = "02000000013ba3edfd7a7b12b27ac72c3e67768f617fc81bc3888a51323a9fb8aa4b1e5e4a0100000000ffffffff01e0930400000000001600" +
+	"14751e76e8199196d454941c45d1b3a323f1433bd600000000"
+
+func TestRoute_SignTransaction_ReturnNormal(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := request.SignTransaction{
+		Hex: testUnsignedTxHex,
+		PrevOuts: []request.PrevOut{
+			{Value: 500000, ScriptPubKey: "0014c0cebcd6c3d3ca8c75dc5ec62ebe55330ef910e2", Path: "m/84'/0'/0'/0/0"},
+		},
+		Mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.SignTransaction(w, r)
+
+	var res response.SignedTransaction
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	assert.Equal(t, "020000000001", res.Hex[:12], "Expected a segwit serialization")
+	assert.Equal(t, "3ba3edfd7a7b12b27ac72c3e67768f617fc81bc3888a51323a9fb8aa4b1e5e4a", res.Hex[14:78], "Incorrect input")
+	assert.Len(t, res.TxID, 64, "Incorrect txid")
+	assert.Equal(t, int64(110), res.VirtualSize, "Incorrect virtual size")
+}
+
+func TestRoute_SignTransaction_ReturnInvalidPathError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := request.SignTransaction{
+		Hex: testUnsignedTxHex,
+		PrevOuts: []request.PrevOut{
+			{Value: 500000, ScriptPubKey: "0014c0cebcd6c3d3ca8c75dc5ec62ebe55330ef910e2", Path: "m/84'/0'/0'/0/1"},
+		},
+		Mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.SignTransaction(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedCode = "INVALID_PATH"
+
+	assert.Equal(t, expectedCode, res.Code, "Expected error error: key not owning the output")
+}
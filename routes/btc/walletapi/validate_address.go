@@ -0,0 +1,59 @@
+package walletapi
+
+import (
+	"btcwalletapi/cryto/segwit"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+var errAddressNetwork = errors.New("address does not belong to the network")
+
+// ValidateAddress handle address validation request, an invalid address is reported with the reason
+func (api *BTCWalletAPI) ValidateAddress(res http.ResponseWriter, req *http.Request) {
+	res.Header().Set("Content-Type", "application/json")
+	var reqBody request.AddressValidation
+	json.NewDecoder(req.Body).Decode(&reqBody)
+
+	// resolve network, any network is accepted when none is requested
+	var network *chaincfg.Params
+	var err error
+	if reqBody.Network != "" {
+		network, err = segwit.ParseNetwork(reqBody.Network)
+		if err != nil {
+			log.Println(err)
+			res.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidNetwork))
+			return
+		}
+	}
+
+	// decode address
+	var result = response.AddressValidation{Address: reqBody.Address}
+	info, err := segwit.DecodeAddress(reqBody.Address)
+	if err == nil && network != nil && !info.BelongsTo(network) {
+		err = errAddressNetwork
+	}
+	if err != nil {
+		result.Error = err.Error()
+		json.NewEncoder(res).Encode(result)
+		return
+	}
+
+	result.Valid = true
+	result.Networks = info.Networks
+	result.Type = string(info.Type)
+	result.ScriptPubKey = hex.EncodeToString(info.ScriptPubKey)
+	if info.WitnessVersion >= 0 {
+		result.WitnessVersion = &info.WitnessVersion
+		result.WitnessProgram = hex.EncodeToString(info.WitnessProgram)
+	}
+
+	json.NewEncoder(res).Encode(result)
+}
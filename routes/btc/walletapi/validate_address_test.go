@@ -0,0 +1,72 @@
+package walletapi
+
+import (
+	"bytes"
+	"btcwalletapi/http/response"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRoute_ValidateAddress_ReturnNormal(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := struct {
+		Address string `json:"address"`
+	}{
+		Address: "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.ValidateAddress(w, r)
+
+	var res response.AddressValidation
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	assert.True(t, res.Valid, "Expected a valid address")
+	assert.Equal(t, "p2tr", res.Type, "Incorrect address type")
+	assert.Equal(t, []string{"mainnet"}, res.Networks, "Incorrect networks")
+	assert.Equal(t, 1, *res.WitnessVersion, "Incorrect witness version")
+	assert.Equal(t, "512079be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798", res.ScriptPubKey, "Incorrect scriptPubKey")
+}
+
+func TestRoute_ValidateAddress_ReturnInvalid(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := struct {
+		Address string `json:"address"`
+		Network string `json:"network"`
+	}{
+		Address: "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kemeawh",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.ValidateAddress(w, r)
+
+	var res response.AddressValidation
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	assert.False(t, res.Valid, "Expected an invalid address")
+	assert.Equal(t, "witness version 0 requires a bech32 checksum", res.Error, "Expected the bech32m checksum mismatch")
+
+	params.Address = "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2"
+	params.Network = "testnet3"
+	paramsByte, _ = json.Marshal(params)
+	r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	w = httptest.NewRecorder()
+
+	api.ValidateAddress(w, r)
+
+	res = response.AddressValidation{}
+	err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	assert.False(t, res.Valid, "Expected a mainnet address to be invalid on testnet")
+}
@@ -0,0 +1,52 @@
+package walletapi
+
+import (
+	"btcwalletapi/cryto/mnemonic"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// ValidateMnemonic handle mnemonic validation request, following BIP39 standard
+func (api *BTCWalletAPI) ValidateMnemonic(res http.ResponseWriter, req *http.Request) {
+	res.Header().Set("Content-Type", "application/json")
+	var reqBody request.MnemonicValidation
+	json.NewDecoder(req.Body).Decode(&reqBody)
+
+	// validate mnemonic
+	var validation, err = mnemonic.Validate(reqBody.Mnemonic, reqBody.Language)
+	if err != nil {
+		log.Println(err)
+		res.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(res).Encode(response.GetResponse(response.ErrInvalidInput))
+		return
+	}
+
+	var result = response.MnemonicValidation{
+		Valid:          validation.Valid,
+		Language:       validation.Language,
+		WordCount:      validation.WordCount,
+		ValidWordCount: validation.ValidWordCount,
+		UnknownWords:   make([]response.UnknownWord, 0, len(validation.UnknownWords)),
+		ChecksumValid:  validation.ChecksumValid,
+	}
+	for _, unknown := range validation.UnknownWords {
+		result.UnknownWords = append(result.UnknownWords, response.UnknownWord{
+			Index:       unknown.Index,
+			Word:        unknown.Word,
+			Suggestions: unknown.Suggestions,
+		})
+	}
+
+	// complete a mnemonic missing its last word
+	if !validation.ValidWordCount && len(validation.UnknownWords) == 0 {
+		words, err := mnemonic.CompleteLastWord(reqBody.Mnemonic, validation.Language)
+		if err == nil {
+			result.ChecksumWords = words
+		}
+	}
+
+	json.NewEncoder(res).Encode(result)
+}
@@ -0,0 +1,81 @@
+package walletapi
+
+import (
+	"bytes"
+	"btcwalletapi/http/response"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRoute_ValidateMnemonic_ReturnNormal(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := struct {
+		Mnemonic string `json:"mnemonic"`
+	}{
+		Mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abuot",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.ValidateMnemonic(w, r)
+
+	var res response.MnemonicValidation
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	assert.False(t, res.Valid, "Expected an invalid mnemonic")
+	assert.Len(t, res.UnknownWords, 1, "Expected one unknown word")
+	assert.Contains(t, res.UnknownWords[0].Suggestions, "about", "Expected the intended word to be suggested")
+}
+
+func TestRoute_ValidateMnemonic_ReturnChecksumWords(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := struct {
+		Mnemonic string `json:"mnemonic"`
+	}{
+		Mnemonic: "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.ValidateMnemonic(w, r)
+
+	var res response.MnemonicValidation
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	assert.False(t, res.ValidWordCount, "Expected an incomplete mnemonic")
+	assert.Len(t, res.ChecksumWords, 8, "Expected every valid last word")
+}
+
+func TestRoute_ValidateMnemonic_ReturnInvalidInputError(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := struct {
+		Mnemonic string `json:"mnemonic"`
+		Language string `json:"language"`
+	}{
+		Mnemonic: "abandon",
+		Language: "klingon",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.ValidateMnemonic(w, r)
+
+	var res response.ErrorResponse
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+
+	var expectedCode = "INVALID_INPUT"
+
+	assert.Equal(t, expectedCode, res.Code, "Expected error error: unsupported language")
+}
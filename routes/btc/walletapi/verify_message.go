@@ -0,0 +1,29 @@
+package walletapi
+
+import (
+	"btcwalletapi/cryto/message"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/json"
+	"net/http"
+)
+
+// VerifyMessage handle message verification request, a signature not verifying is reported with the reason
+func (api *BTCWalletAPI) VerifyMessage(res http.ResponseWriter, req *http.Request) {
+	res.Header().Set("Content-Type", "application/json")
+	var reqBody request.VerifyMessage
+	json.NewDecoder(req.Body).Decode(&reqBody)
+
+	var result = response.MessageVerification{Address: reqBody.Address}
+	format, err := message.Verify(reqBody.Address, reqBody.Message, reqBody.Signature)
+	result.Format = string(format)
+	if err != nil {
+		result.Error = err.Error()
+		json.NewEncoder(res).Encode(result)
+		return
+	}
+
+	result.Valid = true
+
+	json.NewEncoder(res).Encode(result)
+}
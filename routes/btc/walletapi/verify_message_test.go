@@ -0,0 +1,56 @@
+package walletapi
+
+import (
+	"bytes"
+	"btcwalletapi/http/request"
+	"btcwalletapi/http/response"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRoute_VerifyMessage_ReturnNormal(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	// BIP322 test vector
+	params := request.VerifyMessage{
+		Address:   "bc1q9vza2e8x573nczrlzms0wvx3gsqjx7vavgkx0l",
+		Message:   "Hello World",
+		Signature: "AkcwRAIgZRfIY3p7/DoVTty6YZbWS71bc5Vct9p9Fia83eRmw2QCICK/ENGfwLtptFluMGs2KsqoNSk89pO7F29zJLUx9a/sASECx/EgAxlkQpQ9hYjgGu6EBCPMVPwVIVJqO4XCsMvViHI=",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.VerifyMessage(w, r)
+
+	var res response.MessageVerification
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	assert.True(t, res.Valid, "Expected a valid signature")
+	assert.Equal(t, "bip322", res.Format, "Incorrect format")
+}
+
+func TestRoute_VerifyMessage_ReturnInvalid(t *testing.T) {
+	var api = BTCWalletAPI{}
+
+	params := request.VerifyMessage{
+		Address:   "bc1q9vza2e8x573nczrlzms0wvx3gsqjx7vavgkx0l",
+		Message:   "Hello World!",
+		Signature: "AkcwRAIgZRfIY3p7/DoVTty6YZbWS71bc5Vct9p9Fia83eRmw2QCICK/ENGfwLtptFluMGs2KsqoNSk89pO7F29zJLUx9a/sASECx/EgAxlkQpQ9hYjgGu6EBCPMVPwVIVJqO4XCsMvViHI=",
+	}
+	paramsByte, _ := json.Marshal(params)
+	var r = httptest.NewRequest("POST", "/", bytes.NewBuffer(paramsByte))
+	var w = httptest.NewRecorder()
+
+	api.VerifyMessage(w, r)
+
+	var res response.MessageVerification
+	var err = json.NewDecoder(w.Body).Decode(&res)
+
+	assert.NoError(t, err, "Expected no error: valid response struct")
+	assert.False(t, res.Valid, "Expected an invalid signature")
+	assert.Equal(t, "signature does not match the address and message", res.Error, "Expected the message mismatch")
+}
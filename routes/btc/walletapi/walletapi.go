@@ -1,18 +1,63 @@
 package walletapi
 
-import "github.com/gorilla/mux"
+import (
+	"btcwalletapi/config"
+	"btcwalletapi/cryto/mnemonic"
+	"btcwalletapi/cryto/segwit"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/gorilla/mux"
+)
 
 // BTCWalletAPI struct to build the DI
 type BTCWalletAPI struct {
-	app app
+	app    app
+	config config.Config
 }
 
 type app interface {
 	GetRouter() *mux.Router
+	GetConfig() config.Config
+}
+
+// defaultMaxRangeCount bound of a batch address derivation when not configured
+const defaultMaxRangeCount = 1000
+
+// maxRangeCount give the configured bound of a batch address derivation
+func (api *BTCWalletAPI) maxRangeCount() uint32 {
+	if api.config.Bitcoin.MaxRangeCount == 0 {
+		return defaultMaxRangeCount
+	}
+	return api.config.Bitcoin.MaxRangeCount
+}
+
+// network resolve the requested network, falling back to the server default
+func (api *BTCWalletAPI) network(name string) (*chaincfg.Params, error) {
+	if name == "" {
+		name = api.config.Bitcoin.Network
+	}
+	return segwit.ParseNetwork(name)
+}
+
+// uncompressedKeys tell whether addresses follow the legacy uncompressed key derivation, as requested
+// or configured server wide
+func (api *BTCWalletAPI) uncompressedKeys(requested bool) bool {
+	return requested || api.config.Bitcoin.LegacyUncompressedKeys
+}
+
+// seed resolve the request seed, a mnemonic and its optional passphrase are used when no raw seed is given
+func seed(raw []byte, words, passphrase string) ([]byte, error) {
+	if len(raw) > 0 || words == "" {
+		return raw, nil
+	}
+	return mnemonic.ToSeed(words, passphrase)
 }
 
 // Register register routes in an app and reserve for DI
 func (api *BTCWalletAPI) Register(a app) {
+	api.app = a
+	api.config = a.GetConfig()
+
 	// @Title Wallet API
 	// @Version 1.0
 	// @Description A BTC Wallet API
@@ -22,30 +67,268 @@ func (api *BTCWalletAPI) Register(a app) {
 	// CreateMnemonic
 	// @Summary		Generate a mnemonic words
 	// @Description Generate a random mnemonic words following BIP39 standard
+	// @Param		words query int false "12, 15, 18, 21 or 24, default 24"
+	// @Param		language query string false "BIP39 wordlist, default english"
 	// @Produce		json
 	// @Success		200 (object) http.response.Mnemonic
+	// @Failure		400 (object) http.response.ErrorResponse
 	// @Failure		500 (object) http.response.ErrorResponse
 	apiV1.HandleFunc("/mnemonic", api.CreateMnemonic).Methods("GET")
 
+	// CreateSeed
+	// @Summary		Convert a mnemonic to its seed
+	// @Description Give the 64 bytes BIP39 seed and the master fingerprint of a checksum validated
+	//				mnemonic and an optional passphrase
+	// @Accept		json http.request.MnemonicSeed
+	// @Produce		json
+	// @Success		200 (object) http.response.Seed
+	// @Failure		400 (object) http.response.ErrorResponse
+	// @Failure		500 (object) http.response.ErrorResponse
+	apiV1.HandleFunc("/mnemonic/seed", api.CreateSeed).Methods("POST")
+
+	// ValidateMnemonic
+	// @Summary		Validate a mnemonic
+	// @Description Report unknown words with their closest suggestions and checksum failures of a mnemonic,
+	//				and every valid last word when 11, 14, 17, 20 or 23 words are given
+	// @Accept		json http.request.MnemonicValidation
+	// @Produce		json
+	// @Success		200 (object) http.response.MnemonicValidation
+	// @Failure		400 (object) http.response.ErrorResponse
+	apiV1.HandleFunc("/mnemonic/validate", api.ValidateMnemonic).Methods("POST")
+
 	// CreateHDSegWitAddress
 	// @Summary		Create a mnemonic words
 	// @Description Generate a Hierarchical Deterministic (HD) Segregated Witness (SegWit)
-	//				bitcoin address from a given seed and path
+	//				bitcoin address from a given seed and path, with the key bundle fields listed by include
 	// @Accept		json http.request.HDSegWit
 	// @Produce		json
-	// @Success		200 (object) http.response.Address
+	// @Success		200 (object) http.response.HDSegWit
 	// @Failure		409 (object) http.response.ErrorResponse
 	// @Failure		500 (object) http.response.ErrorResponse
 	apiV1.HandleFunc("/hd/segwit", api.CreateHDSegWitAddress).Methods("POST")
 
+	// ExportAccountXPub
+	// @Summary		Export an account extended public key
+	// @Description Give the account extended public key (xpub/ypub/zpub, tpub/upub/vpub on test networks)
+	//				of a BIP44/49/84 account path and the master fingerprint, from a given seed
+	// @Accept		json http.request.AccountXPub
+	// @Produce		json
+	// @Success		200 (object) http.response.ExtendedKey
+	// @Failure		400 (object) http.response.ErrorResponse
+	// @Failure		500 (object) http.response.ErrorResponse
+	apiV1.HandleFunc("/hd/xpub", api.ExportAccountXPub).Methods("POST")
+
+	// ExportAccountDescriptors
+	// @Summary		Export the descriptors of an account
+	// @Description Give the receive and change output descriptors with checksum of a BIP44/49/84/86 seed account,
+	//				with xpub keys for watch-only wallets and, when bitcoin.key_export is set, xprv keys for spending wallets
+	// @Accept		json http.request.AccountDescriptors
+	// @Produce		json
+	// @Success		200 (object) http.response.AccountDescriptors
+	// @Failure		400 (object) http.response.ErrorResponse
+	// @Failure		500 (object) http.response.ErrorResponse
+	apiV1.HandleFunc("/hd/descriptors", api.ExportAccountDescriptors).Methods("POST")
+
+	// CreateWatchOnlyAddress
+	// @Summary		Create a watch-only address
+	// @Description Generate a P2PKH, P2SH-P2WPKH, P2WPKH or P2TR bitcoin address from an account
+	//				extended public key and a relative change/index path, without the seed
+	// @Accept		json http.request.WatchOnly
+	// @Produce		json
+	// @Success		200 (object) http.response.Address
+	// @Failure		400 (object) http.response.ErrorResponse
+	// @Failure		500 (object) http.response.ErrorResponse
+	apiV1.HandleFunc("/hd/watchonly", api.CreateWatchOnlyAddress).Methods("POST")
+
+	// CreateAddressRange
+	// @Summary		Create a range of addresses
+	// @Description Generate count consecutive receive or change addresses of an account, from a seed
+	//				and an account path or from an account extended public key
+	// @Accept		json http.request.AddressRange
+	// @Produce		json
+	// @Success		200 (object) http.response.AddressRange
+	// @Failure		400 (object) http.response.ErrorResponse
+	// @Failure		500 (object) http.response.ErrorResponse
+	apiV1.HandleFunc("/hd/range", api.CreateAddressRange).Methods("POST")
+
+	// DeriveKey
+	// @Summary		Derive a key at a path
+	// @Description Derive the BIP32 key at an absolute or relative path of any depth, from a seed or
+	//				from an extended public key, strict BIP44 style validation is opt-in
+	// @Accept		json http.request.DeriveKey
+	// @Produce		json
+	// @Success		200 (object) http.response.DerivedKey
+	// @Failure		400 (object) http.response.ErrorResponse
+	// @Failure		500 (object) http.response.ErrorResponse
+	apiV1.HandleFunc("/hd/derive", api.DeriveKey).Methods("POST")
+
 	// CreateMultiSigP2SHAddress
 	// @Summary		Create a mnemonic words
 	// @Description Generate an n-out-of-m Multisignature (multi-sig) Pay-To-Script-Hash (P2SH)
-	//				bitcoin address, where n, m and public keys can be specified
+	//				bitcoin address, where n, m, public keys, the script type (p2sh, p2sh-p2wsh, p2wsh)
+	//				and BIP67 key sorting can be specified, with its scripts, ASM and output descriptor
 	// @Accept		json http.request.MultiSig
 	// @Produce		json
-	// @Success		200 (object) http.response.Address
+	// @Success		200 (object) http.response.MultiSig
 	// @Failure		409 (object) http.response.ErrorResponse
 	// @Failure		500 (object) http.response.ErrorResponse
 	apiV1.HandleFunc("/multisig", api.CreateMultiSigP2SHAddress).Methods("POST")
+
+	// ValidateAddress
+	// @Summary		Validate an address
+	// @Description Tell whether an address is valid, with its networks, type (P2PKH, P2SH, P2WPKH, P2WSH, P2TR
+	//				or unknown witness version) and scriptPubKey, or the reason it is invalid
+	// @Accept		json http.request.AddressValidation
+	// @Produce		json
+	// @Success		200 (object) http.response.AddressValidation
+	// @Failure		400 (object) http.response.ErrorResponse
+	apiV1.HandleFunc("/address/validate", api.ValidateAddress).Methods("POST")
+
+	// SignMessage
+	// @Summary		Sign a message
+	// @Description Prove the ownership of the address at a BIP44, BIP84 or BIP86 path of a seed, with a legacy
+	//				signmessage signature for P2PKH and a BIP322 simple signature for P2WPKH and P2TR
+	// @Accept		json http.request.SignMessage
+	// @Produce		json
+	// @Success		200 (object) http.response.SignedMessage
+	// @Failure		400 (object) http.response.ErrorResponse
+	// @Failure		500 (object) http.response.ErrorResponse
+	apiV1.HandleFunc("/message/sign", api.SignMessage).Methods("POST")
+
+	// VerifyMessage
+	// @Summary		Verify a message signature
+	// @Description Tell whether a legacy (P2PKH) or BIP322 simple (P2WPKH, P2TR) signature of a message
+	//				matches an address, or the reason it does not
+	// @Accept		json http.request.VerifyMessage
+	// @Produce		json
+	// @Success		200 (object) http.response.MessageVerification
+	apiV1.HandleFunc("/message/verify", api.VerifyMessage).Methods("POST")
+
+	// CreateUnsignedTransaction
+	// @Summary		Create an unsigned transaction
+	// @Description Select the UTXOs paying the outputs at a fee rate (branch and bound, largest first fallback),
+	//				add a change output at the change path address of the seed and give the fee breakdown
+	// @Accept		json http.request.UnsignedTransaction
+	// @Produce		json
+	// @Success		200 (object) http.response.UnsignedTransaction
+	// @Failure		400 (object) http.response.ErrorResponse
+	// @Failure		500 (object) http.response.ErrorResponse
+	apiV1.HandleFunc("/transaction", api.CreateUnsignedTransaction).Methods("POST")
+
+	// SignTransaction
+	// @Summary		Sign a raw transaction
+	// @Description Sign every input of an unsigned transaction with the seed key at its path, P2PKH,
+	//				P2SH-P2WPKH and P2WPKH with SIGHASH_ALL, P2TR on the BIP86 key path with SIGHASH_DEFAULT
+	// @Accept		json http.request.SignTransaction
+	// @Produce		json
+	// @Success		200 (object) http.response.SignedTransaction
+	// @Failure		400 (object) http.response.ErrorResponse
+	// @Failure		500 (object) http.response.ErrorResponse
+	apiV1.HandleFunc("/transaction/sign", api.SignTransaction).Methods("POST")
+
+	// CreatePSBT
+	// @Summary		Create a PSBT
+	// @Description Select the UTXOs as /transaction does and give a BIP174 PSBT with the BIP32 derivations
+	//				of the seed keys, the cosigner keys of multisig UTXOs and the change
+	// @Accept		json http.request.CreatePSBT
+	// @Produce		json
+	// @Success		200 (object) http.response.PSBT
+	// @Failure		400 (object) http.response.ErrorResponse
+	// @Failure		500 (object) http.response.ErrorResponse
+	apiV1.HandleFunc("/psbt", api.CreatePSBT).Methods("POST")
+
+	// SignPSBT
+	// @Summary		Sign a PSBT
+	// @Description Sign every PSBT input holding a BIP32 derivation of the seed
+	// @Accept		json http.request.SignPSBT
+	// @Produce		json
+	// @Success		200 (object) http.response.PSBT
+	// @Failure		400 (object) http.response.ErrorResponse
+	// @Failure		500 (object) http.response.ErrorResponse
+	apiV1.HandleFunc("/psbt/sign", api.SignPSBT).Methods("POST")
+
+	// CombinePSBT
+	// @Summary		Combine PSBTs
+	// @Description Merge the partial signatures and metadata of PSBTs of the same transaction
+	// @Accept		json http.request.CombinePSBT
+	// @Produce		json
+	// @Success		200 (object) http.response.PSBT
+	// @Failure		400 (object) http.response.ErrorResponse
+	// @Failure		500 (object) http.response.ErrorResponse
+	apiV1.HandleFunc("/psbt/combine", api.CombinePSBT).Methods("POST")
+
+	// FinalizePSBT
+	// @Summary		Finalize a PSBT
+	// @Description Build the final scriptSig and witness of every input holding enough signatures
+	// @Accept		json http.request.PSBT
+	// @Produce		json
+	// @Success		200 (object) http.response.PSBT
+	// @Failure		400 (object) http.response.ErrorResponse
+	// @Failure		500 (object) http.response.ErrorResponse
+	apiV1.HandleFunc("/psbt/finalize", api.FinalizePSBT).Methods("POST")
+
+	// ExtractPSBT
+	// @Summary		Extract the transaction of a PSBT
+	// @Description Give the network ready transaction of a finalized PSBT
+	// @Accept		json http.request.PSBT
+	// @Produce		json
+	// @Success		200 (object) http.response.SignedTransaction
+	// @Failure		400 (object) http.response.ErrorResponse
+	// @Failure		500 (object) http.response.ErrorResponse
+	apiV1.HandleFunc("/psbt/extract", api.ExtractPSBT).Methods("POST")
+
+	// CreateHDMultiSigAddress
+	// @Summary		Create a range of HD multisig addresses
+	// @Description Derive the child key of every cosigner extended public key and generate count consecutive
+	//				m-of-n multisig addresses with BIP67 sorted keys, following BIP45/BIP48 key origins
+	// @Accept		json http.request.HDMultiSig
+	// @Produce		json
+	// @Success		200 (object) http.response.HDMultiSig
+	// @Failure		400 (object) http.response.ErrorResponse
+	// @Failure		500 (object) http.response.ErrorResponse
+	apiV1.HandleFunc("/multisig/hd", api.CreateHDMultiSigAddress).Methods("POST")
+
+	// DecodeMultiSigScript
+	// @Summary		Decode a multisig script
+	// @Description Give m, n and the public keys of a multisig redeem or witness script, with its
+	//				P2SH, P2SH-P2WSH and P2WSH addresses on every network
+	// @Accept		json http.request.DecodeScript
+	// @Produce		json
+	// @Success		200 (object) http.response.DecodedScript
+	// @Failure		400 (object) http.response.ErrorResponse
+	// @Failure		500 (object) http.response.ErrorResponse
+	apiV1.HandleFunc("/multisig/decode", api.DecodeMultiSigScript).Methods("POST")
+
+	// DeriveDescriptor
+	// @Summary		Derive the addresses of an output descriptor
+	// @Description Parse a pkh, sh(wpkh), wpkh, tr, sh(multi), sh(wsh(multi)) or wsh(multi) descriptor (sortedmulti
+	//				variants included) with key origins, xpubs and /0/* ranges, verify its checksum and give the
+	//				addresses of an index range
+	// @Accept		json http.request.DeriveDescriptor
+	// @Produce		json
+	// @Success		200 (object) http.response.Descriptor
+	// @Failure		400 (object) http.response.ErrorResponse
+	// @Failure		500 (object) http.response.ErrorResponse
+	apiV1.HandleFunc("/descriptor/derive", api.DeriveDescriptor).Methods("POST")
+
+	// ExportKey
+	// @Summary		Export a private key
+	// @Description Give the WIF, hex private and public keys and the address at a path of a seed, disabled
+	//				unless bitcoin.key_export is set in config.yaml
+	// @Accept		json http.request.ExportKey
+	// @Produce		json
+	// @Success		200 (object) http.response.ExportedKey
+	// @Failure		400 (object) http.response.ErrorResponse
+	// @Failure		403 (object) http.response.ErrorResponse
+	// @Failure		500 (object) http.response.ErrorResponse
+	apiV1.HandleFunc("/key/export", api.ExportKey).Methods("POST")
+
+	// ImportKey
+	// @Summary		Import a WIF private key
+	// @Description Give the P2PKH, P2SH-P2WPKH, P2WPKH and P2TR addresses a WIF private key controls
+	// @Accept		json http.request.ImportKey
+	// @Produce		json
+	// @Success		200 (object) http.response.ImportedKey
+	// @Failure		400 (object) http.response.ErrorResponse
+	apiV1.HandleFunc("/key/import", api.ImportKey).Methods("POST")
 }
\ No newline at end of file